@@ -0,0 +1,262 @@
+package config
+
+import (
+	"errors"
+	"p2gc3/model"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+// systemMuscleGroups is the seeded muscle group taxonomy
+var systemMuscleGroups = []model.MuscleGroup{
+	{Name: "chest", Region: model.RegionUpper},
+	{Name: "lats", Region: model.RegionUpper},
+	{Name: "upper_back", Region: model.RegionUpper},
+	{Name: "traps", Region: model.RegionUpper},
+	{Name: "front_delts", Region: model.RegionUpper},
+	{Name: "side_delts", Region: model.RegionUpper},
+	{Name: "rear_delts", Region: model.RegionUpper},
+	{Name: "biceps", Region: model.RegionUpper},
+	{Name: "triceps", Region: model.RegionUpper},
+	{Name: "forearms", Region: model.RegionUpper},
+	{Name: "abs", Region: model.RegionCore},
+	{Name: "obliques", Region: model.RegionCore},
+	{Name: "lower_back", Region: model.RegionCore},
+	{Name: "glutes", Region: model.RegionLower},
+	{Name: "quadriceps", Region: model.RegionLower},
+	{Name: "hamstrings", Region: model.RegionLower},
+	{Name: "adductors", Region: model.RegionLower},
+	{Name: "calves", Region: model.RegionLower},
+}
+
+// systemEquipment is the seeded equipment taxonomy
+var systemEquipment = []string{
+	"barbell", "dumbbell", "kettlebell", "bench", "rack", "cable", "machine",
+	"pull_up_bar", "dip_bars", "treadmill", "rower", "bodyweight",
+}
+
+// systemExercise describes a seeded catalog definition and its taxonomy
+type systemExercise struct {
+	Name        string
+	Description string
+	Pattern     string
+	Type        string
+	Primary     []string
+	Secondary   []string
+	Equipment   []string
+}
+
+// systemExercises are the catalog definitions available to every user
+var systemExercises = []systemExercise{
+	{"Bench Press", "Barbell press lying on a flat bench", model.PatternHorizontalPush, model.TypeStrength,
+		[]string{"chest"}, []string{"front_delts", "triceps"}, []string{"barbell", "bench"}},
+	{"Incline Bench Press", "Barbell press on a bench inclined at 30-45 degrees", model.PatternHorizontalPush, model.TypeStrength,
+		[]string{"chest", "front_delts"}, []string{"triceps"}, []string{"barbell", "bench"}},
+	{"Back Squat", "Barbell squat with the bar on the upper back", model.PatternSquat, model.TypeStrength,
+		[]string{"quadriceps", "glutes"}, []string{"adductors", "lower_back"}, []string{"barbell", "rack"}},
+	{"Front Squat", "Barbell squat with the bar racked on the front delts", model.PatternSquat, model.TypeStrength,
+		[]string{"quadriceps"}, []string{"glutes", "upper_back", "abs"}, []string{"barbell", "rack"}},
+	{"Deadlift", "Barbell hinge lifting the bar from the floor", model.PatternHinge, model.TypeStrength,
+		[]string{"glutes", "hamstrings", "lower_back"}, []string{"traps", "forearms", "quadriceps"}, []string{"barbell"}},
+	{"Romanian Deadlift", "Hip hinge with soft knees, bar kept close to the legs", model.PatternHinge, model.TypeStrength,
+		[]string{"hamstrings", "glutes"}, []string{"lower_back", "forearms"}, []string{"barbell"}},
+	{"Overhead Press", "Standing barbell press overhead", model.PatternVerticalPush, model.TypeStrength,
+		[]string{"front_delts"}, []string{"side_delts", "triceps", "abs"}, []string{"barbell", "rack"}},
+	{"Barbell Row", "Bent-over row pulling the barbell to the torso", model.PatternHorizontalPull, model.TypeStrength,
+		[]string{"upper_back", "lats"}, []string{"rear_delts", "biceps", "lower_back"}, []string{"barbell"}},
+	{"Pull-up", "Vertical pull from a dead hang, overhand grip", model.PatternVerticalPull, model.TypeBodyweight,
+		[]string{"lats"}, []string{"biceps", "upper_back", "forearms"}, []string{"pull_up_bar"}},
+	{"Chin-up", "Vertical pull from a dead hang, underhand grip", model.PatternVerticalPull, model.TypeBodyweight,
+		[]string{"lats", "biceps"}, []string{"upper_back", "forearms"}, []string{"pull_up_bar"}},
+	{"Dip", "Bodyweight press on parallel bars", model.PatternVerticalPush, model.TypeBodyweight,
+		[]string{"triceps", "chest"}, []string{"front_delts"}, []string{"dip_bars"}},
+	{"Lunge", "Split-stance single leg squat", model.PatternLunge, model.TypeStrength,
+		[]string{"quadriceps", "glutes"}, []string{"hamstrings", "adductors"}, []string{"dumbbell"}},
+	{"Leg Press", "Machine press with the legs", model.PatternSquat, model.TypeStrength,
+		[]string{"quadriceps", "glutes"}, []string{"adductors"}, []string{"machine"}},
+	{"Lat Pulldown", "Cable vertical pull to the upper chest", model.PatternVerticalPull, model.TypeStrength,
+		[]string{"lats"}, []string{"biceps", "upper_back"}, []string{"cable"}},
+	{"Bicep Curl", "Elbow flexion with dumbbells or barbell", model.PatternIsolation, model.TypeStrength,
+		[]string{"biceps"}, []string{"forearms"}, []string{"dumbbell"}},
+	{"Tricep Extension", "Elbow extension with cable or dumbbell", model.PatternIsolation, model.TypeStrength,
+		[]string{"triceps"}, nil, []string{"cable"}},
+	{"Plank", "Isometric front support hold", model.PatternCore, model.TypeTimedHold,
+		[]string{"abs"}, []string{"obliques", "lower_back"}, []string{"bodyweight"}},
+	{"Running", "Steady state or interval running", model.PatternCardio, model.TypeDistanceCardio,
+		[]string{"quadriceps", "calves"}, []string{"hamstrings", "glutes"}, []string{"treadmill"}},
+	{"Rowing", "Ergometer rowing", model.PatternCardio, model.TypeDistanceCardio,
+		[]string{"upper_back", "quadriceps"}, []string{"lats", "hamstrings", "biceps"}, []string{"rower"}},
+}
+
+// normalizeExerciseName is the key used to match exercises to catalog definitions
+func normalizeExerciseName(name string) string {
+	return strings.ToLower(strings.TrimSpace(name))
+}
+
+// SeedTaxonomy inserts the muscle groups and equipment that are missing
+func SeedTaxonomy(db *gorm.DB) error {
+	for _, mg := range systemMuscleGroups {
+		m := mg
+		if err := db.Where(model.MuscleGroup{Name: m.Name}).FirstOrCreate(&m).Error; err != nil {
+			return err
+		}
+	}
+	for _, name := range systemEquipment {
+		eq := model.Equipment{Name: name}
+		if err := db.Where(model.Equipment{Name: name}).FirstOrCreate(&eq).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// SeedExerciseCatalog inserts the system definitions that are not in the catalog yet
+// and tags the ones without a taxonomy. It expects SeedTaxonomy to have run.
+func SeedExerciseCatalog(db *gorm.DB) error {
+	for _, se := range systemExercises {
+		var def model.ExerciseDefinition
+		err := db.Where("user_id IS NULL AND LOWER(TRIM(name)) = ?", normalizeExerciseName(se.Name)).
+			First(&def).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			def = model.ExerciseDefinition{Name: se.Name, Description: se.Description, ExerciseType: se.Type}
+			if err := db.Create(&def).Error; err != nil {
+				return err
+			}
+		} else if err != nil {
+			return err
+		}
+
+		// System definitions are owned by the seed, keep their type in sync
+		if def.ExerciseType != se.Type {
+			if err := db.Model(&def).Update("exercise_type", se.Type).Error; err != nil {
+				return err
+			}
+		}
+
+		if def.MovementPattern != "" {
+			continue
+		}
+
+		var primary, secondary []model.MuscleGroup
+		var equipment []model.Equipment
+		if err := db.Where("name IN ?", se.Primary).Find(&primary).Error; err != nil {
+			return err
+		}
+		if len(se.Secondary) > 0 {
+			if err := db.Where("name IN ?", se.Secondary).Find(&secondary).Error; err != nil {
+				return err
+			}
+		}
+		if err := db.Where("name IN ?", se.Equipment).Find(&equipment).Error; err != nil {
+			return err
+		}
+
+		if err := ReplaceTaxonomy(db, &def, se.Pattern, primary, secondary, equipment); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ReplaceTaxonomy overwrites the movement pattern, muscle groups and equipment of a catalog definition
+func ReplaceTaxonomy(db *gorm.DB, def *model.ExerciseDefinition, pattern string, primary, secondary []model.MuscleGroup, equipment []model.Equipment) error {
+	if err := db.Model(def).Update("movement_pattern", pattern).Error; err != nil {
+		return err
+	}
+	if err := db.Model(def).Association("PrimaryMuscles").Replace(primary); err != nil {
+		return err
+	}
+	if err := db.Model(def).Association("SecondaryMuscles").Replace(secondary); err != nil {
+		return err
+	}
+	return db.Model(def).Association("Equipment").Replace(equipment)
+}
+
+// FindOrCreateDefinition returns the catalog definition matching name for the user,
+// preferring system definitions, and creates a custom one of exerciseType when nothing matches
+func FindOrCreateDefinition(db *gorm.DB, userID uint, name, description, exerciseType string) (model.ExerciseDefinition, error) {
+	var def model.ExerciseDefinition
+	err := db.
+		Where("(user_id IS NULL OR user_id = ?) AND LOWER(TRIM(name)) = ?", userID, normalizeExerciseName(name)).
+		Order("user_id NULLS FIRST").
+		First(&def).Error
+	if err == nil {
+		return def, nil
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return def, err
+	}
+
+	def = model.ExerciseDefinition{
+		UserID:       &userID,
+		Name:         strings.TrimSpace(name),
+		Description:  description,
+		ExerciseType: exerciseType,
+	}
+	err = db.Create(&def).Error
+	return def, err
+}
+
+// MigrateExerciseCatalog links every exercise without a definition to the catalog,
+// deduplicating exercises with the same name per user into one definition
+func MigrateExerciseCatalog(db *gorm.DB) error {
+	type unlinkedExercise struct {
+		ID          uint
+		Name        string
+		Description string
+		UserID      uint
+	}
+
+	var rows []unlinkedExercise
+	if err := db.Table("exercises").
+		Select("exercises.id, exercises.name, exercises.description, workouts.user_id").
+		Joins("JOIN workouts ON workouts.id = exercises.workout_id").
+		Where("exercises.definition_id IS NULL").
+		Order("exercises.id").
+		Scan(&rows).Error; err != nil {
+		return err
+	}
+
+	err := db.Transaction(func(tx *gorm.DB) error {
+		// definition ID -> exercise IDs to link
+		links := map[uint][]uint{}
+		// user ID -> normalized name -> definition ID, avoids a lookup per row
+		resolved := map[uint]map[string]uint{}
+
+		for _, r := range rows {
+			key := normalizeExerciseName(r.Name)
+			if resolved[r.UserID] == nil {
+				resolved[r.UserID] = map[string]uint{}
+			}
+
+			defID, ok := resolved[r.UserID][key]
+			if !ok {
+				def, err := FindOrCreateDefinition(tx, r.UserID, r.Name, r.Description, model.TypeStrength)
+				if err != nil {
+					return err
+				}
+				defID = def.ID
+				resolved[r.UserID][key] = defID
+			}
+			links[defID] = append(links[defID], r.ID)
+		}
+
+		for defID, exerciseIDs := range links {
+			if err := tx.Model(&model.Exercise{}).
+				Where("id IN ?", exerciseIDs).
+				Update("definition_id", defID).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	// Logs remember the definition their exercise referenced when they were recorded
+	return db.Exec(`UPDATE exercise_logs SET definition_id = exercises.definition_id
+		FROM exercises
+		WHERE exercises.id = exercise_logs.exercise_id AND exercise_logs.definition_id IS NULL`).Error
+}
@@ -0,0 +1,24 @@
+package config
+
+import "gorm.io/gorm"
+
+// enrollmentMigrations keep a single active program enrollment per user. Duplicates left by
+// concurrent enrollments are closed first, the most recent one stays active.
+var enrollmentMigrations = []string{
+	`UPDATE program_enrollments SET active = false
+		WHERE active AND id NOT IN (
+			SELECT MAX(id) FROM program_enrollments WHERE active GROUP BY user_id
+		)`,
+	`CREATE UNIQUE INDEX IF NOT EXISTS idx_program_enrollments_active_user
+		ON program_enrollments (user_id) WHERE active`,
+}
+
+// MigrateEnrollmentIndex creates the index allowing one active enrollment per user, safe to run on every start
+func MigrateEnrollmentIndex(db *gorm.DB) error {
+	for _, stmt := range enrollmentMigrations {
+		if err := db.Exec(stmt).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
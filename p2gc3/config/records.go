@@ -0,0 +1,125 @@
+package config
+
+import (
+	helper "p2gc3/helpers"
+	"p2gc3/model"
+
+	"gorm.io/gorm"
+)
+
+// recordBatchSize is the number of personal records inserted per statement when rebuilding them
+const recordBatchSize = 500
+
+// HistoryScope limits a query of table, personal_records or exercise_logs, to the rows of the
+// catalog entry a log or exercise references, or of the exercise itself when it is not linked
+// to the catalog. Swapping an exercise changes its catalog entry, older logs stay with the old one.
+func HistoryScope(db *gorm.DB, table string, userID uint, definitionID *uint, exerciseID uint) *gorm.DB {
+	db = db.Where(table+".user_id = ?", userID)
+	if definitionID != nil {
+		return db.Where(table+".definition_id = ?", *definitionID)
+	}
+	return db.Where(table+".definition_id IS NULL AND "+table+".exercise_id = ?", exerciseID)
+}
+
+// RecomputePersonalRecords rebuilds the personal records of one scope by replaying its logs in
+// the order they were done. Imports and edits can change logs older than the current records.
+func RecomputePersonalRecords(tx *gorm.DB, userID uint, definitionID *uint, exerciseID uint) error {
+	if err := HistoryScope(tx, "personal_records", userID, definitionID, exerciseID).Delete(&model.PersonalRecord{}).Error; err != nil {
+		return err
+	}
+
+	exerciseType := model.TypeStrength
+	if definitionID != nil {
+		var definition model.ExerciseDefinition
+		if err := tx.Select("exercise_type").First(&definition, *definitionID).Error; err != nil {
+			return err
+		}
+		exerciseType = definition.ExerciseType
+	}
+	if exerciseType != model.TypeStrength && exerciseType != model.TypeBodyweight {
+		return nil
+	}
+
+	var logs []model.ExerciseLog
+	if err := HistoryScope(tx, "exercise_logs", userID, definitionID, exerciseID).
+		Preload("Sets", func(db *gorm.DB) *gorm.DB { return db.Order("position") }).
+		Order("created_at, id").
+		Find(&logs).Error; err != nil {
+		return err
+	}
+
+	best := map[string]float64{}
+	var records []model.PersonalRecord
+	for _, log := range logs {
+		candidates := helper.RecordCandidates(helper.RecordSets(exerciseType, log))
+		records = append(records, helper.BeatenRecords(log, candidates, best)...)
+	}
+	if len(records) == 0 {
+		return nil
+	}
+	return tx.CreateInBatches(&records, recordBatchSize).Error
+}
+
+// RecomputeLogRecords rebuilds the personal records of every scope the logs belong to
+func RecomputeLogRecords(tx *gorm.DB, logs []model.ExerciseLog) error {
+	type scope struct {
+		userID, definitionID, exerciseID uint
+	}
+
+	done := map[scope]bool{}
+	for _, log := range logs {
+		s := scope{userID: log.UserID, exerciseID: log.ExerciseID}
+		if log.DefinitionID != nil {
+			s.definitionID, s.exerciseID = *log.DefinitionID, 0
+		}
+		if done[s] {
+			continue
+		}
+		done[s] = true
+
+		if err := RecomputePersonalRecords(tx, log.UserID, log.DefinitionID, log.ExerciseID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// BackfillPersonalRecords builds the personal records of every scope with strength or bodyweight
+// logs but no records yet, such as logs stored before records existed. It runs after
+// AutoMigrate and is safe to run on every start.
+func BackfillPersonalRecords(db *gorm.DB) error {
+	var logs []model.ExerciseLog
+	if err := db.Table("exercise_logs l").
+		Distinct("l.user_id, l.definition_id, CASE WHEN l.definition_id IS NULL THEN l.exercise_id ELSE 0 END AS exercise_id").
+		Joins("LEFT JOIN exercise_definitions d ON d.id = l.definition_id").
+		Where("l.definition_id IS NULL OR d.exercise_type IN ?", []string{model.TypeStrength, model.TypeBodyweight}).
+		Where(`NOT EXISTS (SELECT 1 FROM personal_records r WHERE r.user_id = l.user_id AND
+			(r.definition_id = l.definition_id OR (l.definition_id IS NULL AND r.definition_id IS NULL AND r.exercise_id = l.exercise_id)))`).
+		Scan(&logs).Error; err != nil {
+		return err
+	}
+	if len(logs) == 0 {
+		return nil
+	}
+
+	return db.Transaction(func(tx *gorm.DB) error {
+		return RecomputeLogRecords(tx, logs)
+	})
+}
+
+// DeleteLogs deletes the exercise logs matching query and rebuilds the personal records of their
+// scopes. Records of deleted logs are removed with them, earlier logs can hold the record again.
+func DeleteLogs(tx *gorm.DB, query interface{}, args ...interface{}) error {
+	var logs []model.ExerciseLog
+	if err := tx.Select("id, user_id, definition_id, exercise_id").Where(query, args...).Find(&logs).Error; err != nil {
+		return err
+	}
+	if len(logs) == 0 {
+		return nil
+	}
+
+	if err := tx.Where(query, args...).Delete(&model.ExerciseLog{}).Error; err != nil {
+		return err
+	}
+	return RecomputeLogRecords(tx, logs)
+}
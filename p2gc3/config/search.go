@@ -0,0 +1,40 @@
+package config
+
+import "gorm.io/gorm"
+
+// SearchConfig is the PostgreSQL text search configuration used by search vectors and queries
+const SearchConfig = "english"
+
+// searchMigrations add generated tsvector columns and their GIN indexes.
+// Names weigh more (A) than descriptions (B) in ranking.
+var searchMigrations = []string{
+	`ALTER TABLE workouts ADD COLUMN IF NOT EXISTS search_vector tsvector
+		GENERATED ALWAYS AS (
+			setweight(to_tsvector('` + SearchConfig + `', coalesce(name, '')), 'A') ||
+			setweight(to_tsvector('` + SearchConfig + `', coalesce(description, '')), 'B')
+		) STORED`,
+	`CREATE INDEX IF NOT EXISTS idx_workouts_search_vector ON workouts USING GIN (search_vector)`,
+
+	`ALTER TABLE exercises ADD COLUMN IF NOT EXISTS search_vector tsvector
+		GENERATED ALWAYS AS (
+			setweight(to_tsvector('` + SearchConfig + `', coalesce(name, '')), 'A') ||
+			setweight(to_tsvector('` + SearchConfig + `', coalesce(description, '')), 'B')
+		) STORED`,
+	`CREATE INDEX IF NOT EXISTS idx_exercises_search_vector ON exercises USING GIN (search_vector)`,
+
+	`ALTER TABLE exercise_logs ADD COLUMN IF NOT EXISTS search_vector tsvector
+		GENERATED ALWAYS AS (
+			setweight(to_tsvector('` + SearchConfig + `', coalesce(notes, '')), 'B')
+		) STORED`,
+	`CREATE INDEX IF NOT EXISTS idx_exercise_logs_search_vector ON exercise_logs USING GIN (search_vector)`,
+}
+
+// MigrateSearchIndexes creates the full-text search columns and indexes, safe to run on every start
+func MigrateSearchIndexes(db *gorm.DB) error {
+	for _, stmt := range searchMigrations {
+		if err := db.Exec(stmt).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,32 @@
+package config
+
+import (
+	"log"
+	"os"
+	helper "p2gc3/helpers"
+	"p2gc3/storage"
+)
+
+var Storage storage.BlobStore
+
+// StorageInit sets up the blob store used for uploaded media.
+// Files live on the local filesystem under MEDIA_STORAGE_DIR (defaults to ./uploads).
+// Media links are signed, so it fails without a signing secret.
+func StorageInit() storage.BlobStore {
+	if err := helper.CheckMediaSigningSecret(); err != nil {
+		log.Fatal("Failed to initialize media storage: ", err)
+	}
+
+	dir := os.Getenv("MEDIA_STORAGE_DIR")
+	if dir == "" {
+		dir = "uploads"
+	}
+
+	store, err := storage.NewLocalStore(dir)
+	if err != nil {
+		log.Fatal("Failed to initialize media storage:", err)
+	}
+
+	Storage = store
+	return store
+}
@@ -0,0 +1,101 @@
+package config
+
+import (
+	"p2gc3/model"
+
+	"gorm.io/gorm"
+)
+
+// syncLockKey is the advisory lock taken per user for every synced write. Versions of a user
+// are then handed out in commit order, so a pull never skips a change that commits late.
+const syncLockKey = `hashtext('sync_entities')`
+
+// syncTables are the synced tables with the entity type their rows are tracked as
+var syncTables = []struct{ table, entity string }{
+	{"workouts", model.SyncWorkout},
+	{"exercises", model.SyncExercise},
+	{"exercise_logs", model.SyncLog},
+}
+
+// syncFunction records a change of a synced row in sync_entities. Exercises belong to the owner
+// of their workout; the ones deleted after their workout have no owner left and are skipped,
+// clients remove them with the workout. A tombstone is never revived, IDs are not reused.
+const syncFunction = `CREATE OR REPLACE FUNCTION record_sync_change() RETURNS trigger AS $$
+DECLARE
+	entity record;
+	owner_id bigint;
+BEGIN
+	IF TG_OP = 'DELETE' THEN
+		entity := OLD;
+	ELSE
+		entity := NEW;
+	END IF;
+
+	IF TG_ARGV[0] = '` + model.SyncExercise + `' THEN
+		SELECT user_id INTO owner_id FROM workouts WHERE id = entity.workout_id;
+	ELSE
+		owner_id := entity.user_id;
+	END IF;
+	IF owner_id IS NULL THEN
+		RETURN NULL;
+	END IF;
+
+	PERFORM pg_advisory_xact_lock(` + syncLockKey + `, owner_id::int);
+	INSERT INTO sync_entities (entity_type, entity_id, user_id, version, deleted, changed_at)
+	VALUES (TG_ARGV[0], entity.id, owner_id, nextval('sync_version_seq'), TG_OP = 'DELETE', now())
+	ON CONFLICT (entity_type, entity_id) DO UPDATE
+		SET version = EXCLUDED.version, deleted = EXCLUDED.deleted, changed_at = EXCLUDED.changed_at
+		WHERE NOT sync_entities.deleted;
+	RETURN NULL;
+END
+$$ LANGUAGE plpgsql`
+
+// syncBackfill gives rows written before sync existed their first version, parents first.
+// Each query takes the entity type.
+var syncBackfill = []struct{ entity, query string }{
+	{model.SyncWorkout, `INSERT INTO sync_entities (entity_type, entity_id, user_id, version, deleted, changed_at)
+		SELECT @entity, w.id, w.user_id, nextval('sync_version_seq'), false, now() FROM workouts w
+		WHERE NOT EXISTS (SELECT 1 FROM sync_entities s WHERE s.entity_type = @entity AND s.entity_id = w.id)
+		ORDER BY w.id`},
+	{model.SyncExercise, `INSERT INTO sync_entities (entity_type, entity_id, user_id, version, deleted, changed_at)
+		SELECT @entity, e.id, w.user_id, nextval('sync_version_seq'), false, now()
+		FROM exercises e JOIN workouts w ON w.id = e.workout_id
+		WHERE NOT EXISTS (SELECT 1 FROM sync_entities s WHERE s.entity_type = @entity AND s.entity_id = e.id)
+		ORDER BY e.id`},
+	{model.SyncLog, `INSERT INTO sync_entities (entity_type, entity_id, user_id, version, deleted, changed_at)
+		SELECT @entity, l.id, l.user_id, nextval('sync_version_seq'), false, now() FROM exercise_logs l
+		WHERE NOT EXISTS (SELECT 1 FROM sync_entities s WHERE s.entity_type = @entity AND s.entity_id = l.id)
+		ORDER BY l.id`},
+}
+
+// MigrateSyncTriggers installs the triggers that track changes for offline sync and backfills
+// existing rows. It runs after AutoMigrate and is safe to run on every start.
+func MigrateSyncTriggers(db *gorm.DB) error {
+	return db.Transaction(func(tx *gorm.DB) error {
+		stmts := []string{`CREATE SEQUENCE IF NOT EXISTS sync_version_seq`, syncFunction}
+		for _, t := range syncTables {
+			stmts = append(stmts,
+				`DROP TRIGGER IF EXISTS sync_`+t.table+` ON `+t.table,
+				`CREATE TRIGGER sync_`+t.table+` AFTER INSERT OR UPDATE OR DELETE ON `+t.table+
+					` FOR EACH ROW EXECUTE FUNCTION record_sync_change('`+t.entity+`')`)
+		}
+		for _, stmt := range stmts {
+			if err := tx.Exec(stmt).Error; err != nil {
+				return err
+			}
+		}
+
+		for _, b := range syncBackfill {
+			if err := tx.Exec(b.query, map[string]interface{}{"entity": b.entity}).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// LockSyncUser takes the sync lock of a user until tx ends. Writes that read sync versions
+// take it first, so they cannot interleave with the triggers of other writes of the user.
+func LockSyncUser(tx *gorm.DB, userID uint) error {
+	return tx.Exec(`SELECT pg_advisory_xact_lock(`+syncLockKey+`, ?)`, int32(userID)).Error
+}
@@ -0,0 +1,38 @@
+package config
+
+import "gorm.io/gorm"
+
+// WeightColumnType is the column type of every weight, stored in kilograms with gram precision
+const WeightColumnType = "numeric(10,3)"
+
+// weightColumns are the weight columns that used to be unitless integers
+var weightColumns = map[string][]string{
+	"users":         {"weight"},
+	"exercise_logs": {"weight", "added_weight", "assisted_weight"},
+	"log_sets":      {"weight"},
+}
+
+// MigrateWeightColumns converts the integer weight columns of an existing database to
+// decimal kilograms. Integer values were always entered in kilograms, so they are kept as is.
+// It runs before AutoMigrate and skips tables and columns that do not exist yet.
+func MigrateWeightColumns(db *gorm.DB) error {
+	for table, columns := range weightColumns {
+		for _, column := range columns {
+			var dataType string
+			if err := db.Raw(`SELECT data_type FROM information_schema.columns
+				WHERE table_schema = CURRENT_SCHEMA() AND table_name = ? AND column_name = ?`, table, column).
+				Scan(&dataType).Error; err != nil {
+				return err
+			}
+			if dataType != "integer" && dataType != "bigint" && dataType != "smallint" {
+				continue
+			}
+
+			if err := db.Exec(`ALTER TABLE "` + table + `" ALTER COLUMN "` + column + `" TYPE ` + WeightColumnType +
+				` USING "` + column + `"::numeric`).Error; err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
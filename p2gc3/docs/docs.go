@@ -15,21 +15,53 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/api/workouts": {
+        "/api/analytics/adherence": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Retrieves all workouts for the authenticated user",
+                "description": "Returns daily streaks (training days in a row) and weekly streaks (weeks in a row reaching weekly_target training days) from the logs, and the share of workouts scheduled by the active program that were done in the window. Rest days of the program never break a daily streak and rest_days unplanned days off in a row are allowed. A scheduled workout counts as done when one of its exercises was logged, or a session of it was started, that day.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "workouts"
+                    "analytics"
+                ],
+                "summary": "Training streaks and adherence",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Unplanned days off in a row that keep the daily streak, defaults to 1",
+                        "name": "rest_days",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Training days that make a week count, defaults to 1",
+                        "name": "weekly_target",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "First day of the adherence window (YYYY-MM-DD), defaults to 27 days before to",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Last day of the adherence window (YYYY-MM-DD), defaults to today",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "IANA timezone days are computed in, defaults to the user's timezone",
+                        "name": "tz",
+                        "in": "query"
+                    }
                 ],
-                "summary": "Get all workouts",
                 "responses": {
                     "200": {
                         "description": "OK",
@@ -42,16 +74,19 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "type": "array",
-                                            "items": {
-                                                "$ref": "#/definitions/dto.WorkoutResponse"
-                                            }
+                                            "$ref": "#/definitions/dto.AdherenceResponse"
                                         }
                                     }
                                 }
                             ]
                         }
                     },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
@@ -65,38 +100,64 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "post": {
+            }
+        },
+        "/api/analytics/volume": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Creates a workout with name and description",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Aggregates tonnage (sets x reps x weight), working sets, reps and average intensity (tonnage per rep) of the user's logs per day, week or month, optionally split by exercise or primary muscle group. Logs with individual sets use their working sets. Periods start at midnight in tz; weeks start on Monday. A log counts fully towards each of its primary muscle groups.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "workouts"
+                    "analytics"
                 ],
-                "summary": "Create a new workout",
+                "summary": "Training volume analytics",
                 "parameters": [
                     {
-                        "description": "Workout data",
-                        "name": "workout",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/dto.CreateOrUpdateWorkoutRequest"
-                        }
+                        "type": "string",
+                        "description": "day, week (default) or month",
+                        "name": "period",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "exercise or muscle, whole period when omitted",
+                        "name": "group_by",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Only logs of the catalog entry this exercise is linked to",
+                        "name": "exercise_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Logged at or after (YYYY-MM-DD or RFC3339)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Logged at or before (YYYY-MM-DD inclusive or RFC3339)",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "IANA timezone periods are computed in, defaults to the user's timezone",
+                        "name": "tz",
+                        "in": "query"
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
                             "allOf": [
                                 {
@@ -106,7 +167,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/dto.WorkoutResponse"
+                                            "$ref": "#/definitions/dto.VolumeAnalyticsResponse"
                                         }
                                     }
                                 }
@@ -124,39 +185,168 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
                     }
                 }
             }
         },
-        "/api/workouts/{id}": {
+        "/api/catalog": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Retrieves a specific workout with exercises",
+                "description": "Retrieves system-provided exercises and the user's custom ones, filterable by taxonomy",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "workouts"
+                    "catalog"
                 ],
-                "summary": "Get workout by ID",
+                "summary": "List the exercise catalog",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Workout ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "type": "string",
+                        "description": "Filter by name",
+                        "name": "q",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by muscle group name",
+                        "name": "muscle",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Only match the muscle group as primary mover",
+                        "name": "primary_only",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by body region (upper, lower, core)",
+                        "name": "region",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by required equipment",
+                        "name": "equipment",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by movement pattern",
+                        "name": "pattern",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by exercise type",
+                        "name": "type",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/dto.WorkoutByIDResponse"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dto.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/dto.CatalogEntryResponse"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Adds a user-owned exercise definition to the catalog",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "catalog"
+                ],
+                "summary": "Add a custom catalog exercise",
+                "parameters": [
+                    {
+                        "description": "Catalog entry payload",
+                        "name": "entry",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.CatalogEntryCreateRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dto.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.CatalogEntryResponse"
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     },
                     "400": {
@@ -171,53 +361,43 @@ const docTemplate = `{
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
-                    "403": {
-                        "description": "Forbidden",
+                    "409": {
+                        "description": "Conflict",
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "500": {
+                        "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     }
                 }
-            },
-            "put": {
+            }
+        },
+        "/api/catalog/{id}": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Updates workout name and description",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Retrieves a catalog exercise with the user's usage aggregated across all workouts",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "workouts"
+                    "catalog"
                 ],
-                "summary": "Update a workout",
+                "summary": "Get a catalog exercise",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Workout ID",
+                        "description": "Catalog exercise ID",
                         "name": "id",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "description": "Updated workout data",
-                        "name": "workout",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/dto.CreateOrUpdateWorkoutRequest"
-                        }
                     }
                 ],
                 "responses": {
@@ -232,7 +412,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/dto.WorkoutDTO"
+                                            "$ref": "#/definitions/dto.CatalogEntryResponse"
                                         }
                                     }
                                 }
@@ -262,30 +442,50 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
                     }
                 }
-            },
-            "delete": {
+            }
+        },
+        "/api/catalog/{id}/taxonomy": {
+            "put": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Deletes a workout and all associated exercises and logs",
+                "description": "Replaces muscle groups, movement pattern and equipment of a user-owned catalog exercise",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "workouts"
+                    "catalog"
                 ],
-                "summary": "Delete a workout",
+                "summary": "Tag a custom catalog exercise",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Workout ID",
+                        "description": "Catalog exercise ID",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "Taxonomy payload",
+                        "name": "taxonomy",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.CatalogTaxonomyRequest"
+                        }
                     }
                 ],
                 "responses": {
@@ -300,7 +500,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/dto.WorkoutDTO"
+                                            "$ref": "#/definitions/dto.CatalogEntryResponse"
                                         }
                                     }
                                 }
@@ -340,14 +540,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/exercise-logs": {
+        "/api/exercises": {
             "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Logs an exercise with set, rep, and weight",
+                "description": "Adds a new exercise to the database",
                 "consumes": [
                     "application/json"
                 ],
@@ -355,17 +555,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "exercise-logs"
+                    "exercises"
                 ],
-                "summary": "Create an exercise log",
+                "summary": "Create a new exercise",
                 "parameters": [
                     {
-                        "description": "Exercise log data",
-                        "name": "log",
+                        "description": "Exercise payload",
+                        "name": "exercise",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/dto.ExerciseLogRequest"
+                            "$ref": "#/definitions/dto.ExerciseCreateRequest"
                         }
                     }
                 ],
@@ -409,25 +609,25 @@ const docTemplate = `{
                 }
             }
         },
-        "/exercise-logs/{id}": {
-            "delete": {
+        "/api/exercises/{id}": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Deletes an exercise log by its ID",
+                "description": "Retrieves a single exercise owned through its workout",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "exercise-logs"
+                    "exercises"
                 ],
-                "summary": "Delete an exercise log",
+                "summary": "Get exercise by ID",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Log ID",
+                        "description": "Exercise ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -437,7 +637,19 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/dto.SuccessResponse"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dto.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.ExerciseResponse"
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     },
                     "400": {
@@ -471,16 +683,14 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/exercises": {
-            "post": {
+            },
+            "put": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Adds a new exercise to the database",
+                "description": "Updates name and description of an exercise. A rename links the exercise to the catalog entry matching the new name unless definition_id is sent",
                 "consumes": [
                     "application/json"
                 ],
@@ -490,23 +700,42 @@ const docTemplate = `{
                 "tags": [
                     "exercises"
                 ],
-                "summary": "Create a new exercise",
+                "summary": "Replace an exercise",
                 "parameters": [
                     {
-                        "description": "Exercise payload",
+                        "type": "integer",
+                        "description": "Exercise ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Updated exercise data",
                         "name": "exercise",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/dto.ExerciseCreateRequest"
+                            "$ref": "#/definitions/dto.ExerciseUpdateRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/dto.SuccessResponse"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dto.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.ExerciseResponse"
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     },
                     "400": {
@@ -540,9 +769,7 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/exercises/{id}": {
+            },
             "delete": {
                 "security": [
                     {
@@ -604,28 +831,65 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/users": {
-            "get": {
+            },
+            "patch": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Returns the authenticated user's data, BMI, and weight category",
+                "description": "Updates only the provided fields of an exercise. A rename links the exercise to the catalog entry matching the new name unless definition_id is sent",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "users"
+                    "exercises"
+                ],
+                "summary": "Partially update an exercise",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Exercise ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Fields to update",
+                        "name": "exercise",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.ExercisePatchRequest"
+                        }
+                    }
                 ],
-                "summary": "Get authenticated user",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/dto.SuccessResponse"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dto.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.ExerciseResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
                     "401": {
@@ -634,44 +898,83 @@ const docTemplate = `{
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
                     "404": {
                         "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
                     }
                 }
             }
         },
-        "/users/login": {
-            "post": {
-                "description": "Authenticates a user and returns JWT",
-                "consumes": [
-                    "application/json"
+        "/api/exercises/{id}/alternatives": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
                 ],
+                "description": "Ranks catalog exercises of the same exercise type that can replace this one by shared muscle groups, movement pattern and available equipment",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "users"
+                    "exercises"
                 ],
-                "summary": "Login user",
+                "summary": "Suggest substitute exercises",
                 "parameters": [
                     {
-                        "description": "Login credentials",
-                        "name": "user",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/dto.UserLoginRequest"
-                        }
+                        "type": "integer",
+                        "description": "Exercise ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma separated equipment available right now, unrestricted when omitted",
+                        "name": "equipment",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Max suggestions, defaults to 10",
+                        "name": "limit",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/dto.SuccessResponse"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dto.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/dto.ExerciseAlternativeResponse"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     },
                     "400": {
@@ -679,39 +982,78 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
                     }
                 }
             }
         },
-        "/users/register": {
-            "post": {
-                "description": "Creates a new user account",
-                "consumes": [
-                    "application/json"
+        "/api/exercises/{id}/media": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
                 ],
+                "description": "Lists media of an exercise with signed, expiring download URLs",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "users"
+                    "exercises"
                 ],
-                "summary": "Register a new user",
+                "summary": "List exercise media",
                 "parameters": [
                     {
-                        "description": "Register payload",
-                        "name": "user",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/dto.UserRegisterRequest"
-                        }
+                        "type": "integer",
+                        "description": "Exercise ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/dto.SuccessResponse"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dto.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/dto.ExerciseMediaResponse"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     },
                     "400": {
@@ -719,181 +1061,5263 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
                     }
                 }
-            }
-        }
-    },
-    "definitions": {
-        "dto.CreateOrUpdateWorkoutRequest": {
-            "type": "object",
-            "properties": {
-                "description": {
-                    "type": "string"
-                },
-                "name": {
-                    "type": "string"
-                }
-            }
-        },
-        "dto.ErrorResponse": {
-            "type": "object",
-            "properties": {
-                "code": {
-                    "type": "string"
-                },
-                "details": {},
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Attaches a demo image (jpeg, png, gif up to 5 MB) or video (mp4, webm up to 50 MB) to an exercise",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "exercises"
+                ],
+                "summary": "Upload exercise media",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Exercise ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "file",
+                        "description": "Image or video file",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dto.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.ExerciseMediaResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "413": {
+                        "description": "Request Entity Too Large",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "415": {
+                        "description": "Unsupported Media Type",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/exercises/{id}/media/{mediaId}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Removes a media attachment and its stored files",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "exercises"
+                ],
+                "summary": "Delete exercise media",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Exercise ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Media ID",
+                        "name": "mediaId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/exercises/{id}/next-target": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Suggests sets, reps and weight for the next session based on recent logs of the catalog entry the exercise is linked to and a progression strategy. Only strength and bodyweight exercises have targets, the weight of bodyweight exercises is the added weight.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "exercises"
+                ],
+                "summary": "Suggest the next target for an exercise",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Exercise ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "linear (default), double or rpe (uses the RPE logged on the last session's sets)",
+                        "name": "strategy",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Weight step in the preferred unit, greater than 0, defaults to 2 kg or 5 lb",
+                        "name": "increment",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Double progression lower bound, defaults to 8",
+                        "name": "rep_min",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Double progression upper bound, defaults to 12",
+                        "name": "rep_max",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "RPE strategy target, defaults to 8",
+                        "name": "target_rpe",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dto.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.NextTargetResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/exercises/{id}/swap": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Points a workout exercise to another catalog exercise of the same exercise type; existing logs stay attached and keep their original catalog exercise",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "exercises"
+                ],
+                "summary": "Swap an exercise for a substitute",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Exercise ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Substitute catalog exercise",
+                        "name": "swap",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.ExerciseSwapRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dto.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.ExerciseSwapResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/export": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Streams the user's logs with their workout and exercise as CSV, JSON or XLSX, oldest first. Every format has the same columns in this order: log_id, logged_at (RFC3339, UTC), workout_id, workout_name, exercise_id, exercise_name, exercise_type, session_id, set_count, rep_count, weight, added_weight, assisted_weight, weight_unit, duration_seconds, distance_meters, pace_seconds_per_km, rest_seconds, tempo, perceived_effort, notes. New columns are only added at the end. CSV text cells starting with =, +, -, @, a tab or a carriage return are prefixed with ' so spreadsheets do not run them as formulas.",
+                "produces": [
+                    "text/csv",
+                    "application/json",
+                    "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+                ],
+                "tags": [
+                    "export"
+                ],
+                "summary": "Export exercise logs",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "csv (default), json or xlsx",
+                        "name": "format",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Only logs of this exercise",
+                        "name": "exercise_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Only logs of exercises in this workout",
+                        "name": "workout_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Logged at or after (YYYY-MM-DD or RFC3339)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Logged at or before (YYYY-MM-DD inclusive or RFC3339)",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Unit of the weight columns, kg or lb, defaults to the preferred unit",
+                        "name": "weight_unit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/helper.ExportRow"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/import/hevy": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Imports the CSV exported by the Hevy app, units are taken from the column names. The sets of an exercise in a workout become one log with its sets; missing workouts and exercises are created and logs already recorded for the same exercise and time are skipped. Nothing is imported when a row is invalid.",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "import"
+                ],
+                "summary": "Import a Hevy export",
+                "parameters": [
+                    {
+                        "type": "file",
+                        "description": "Hevy CSV export",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Validate and report without importing",
+                        "name": "dry_run",
+                        "in": "formData"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dto.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.ImportLogsResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dto.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.ImportLogsResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "413": {
+                        "description": "Request Entity Too Large",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dto.ErrorResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "details": {
+                                            "$ref": "#/definitions/dto.ImportLogsResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/import/logs": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Imports one log per CSV row. Columns are mapped to date, workout, exercise, set_count, rep_count, weight, duration_seconds, distance_meters, notes, tempo, rest_seconds and perceived_effort; unmapped fields use the column with the field name. Missing workouts and exercises are created. Nothing is imported when a row is invalid.",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "import"
+                ],
+                "summary": "Import exercise logs from CSV",
+                "parameters": [
+                    {
+                        "type": "file",
+                        "description": "CSV file with a header row",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "JSON object from field to CSV column, e.g. {\\",
+                        "name": "mapping",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Unit of the weight column, kg or lb, defaults to the preferred unit",
+                        "name": "weight_unit",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Validate and report without importing",
+                        "name": "dry_run",
+                        "in": "formData"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dto.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.ImportLogsResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dto.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.ImportLogsResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "413": {
+                        "description": "Request Entity Too Large",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dto.ErrorResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "details": {
+                                            "$ref": "#/definitions/dto.ImportLogsResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/import/strong": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Imports the CSV exported by the Strong app. The sets of an exercise in a workout become one log with its sets; missing workouts and exercises are created and logs already recorded for the same exercise and time are skipped. Nothing is imported when a row is invalid.",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "import"
+                ],
+                "summary": "Import a Strong export",
+                "parameters": [
+                    {
+                        "type": "file",
+                        "description": "Strong CSV export",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Unit the export was made in, kg or lb, defaults to the preferred unit; distances are read in km, or miles for lb",
+                        "name": "weight_unit",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Validate and report without importing",
+                        "name": "dry_run",
+                        "in": "formData"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dto.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.ImportLogsResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dto.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.ImportLogsResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "413": {
+                        "description": "Request Entity Too Large",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dto.ErrorResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "details": {
+                                            "$ref": "#/definitions/dto.ImportLogsResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/logs": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Lists the user's logs with filters, sorting and cursor pagination",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "exercise-logs"
+                ],
+                "summary": "List exercise logs",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Only logs of this exercise",
+                        "name": "exercise_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Only logs of exercises in this workout",
+                        "name": "workout_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Only logs of this session",
+                        "name": "session_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only logs whose notes match these search terms",
+                        "name": "q",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Logged at or after (YYYY-MM-DD or RFC3339)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Logged at or before (YYYY-MM-DD inclusive or RFC3339)",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "created_at, -created_at (default), weight or -weight",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size, defaults to 20, at most 100",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "next_cursor of the previous page",
+                        "name": "cursor",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dto.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.ExerciseLogPageResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Logs an exercise; required fields depend on its type (strength, bodyweight, timed_hold, distance_cardio, intervals)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "exercise-logs"
+                ],
+                "summary": "Create an exercise log",
+                "parameters": [
+                    {
+                        "description": "Exercise log data",
+                        "name": "log",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.ExerciseLogRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dto.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.ExerciseLogResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/logs/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieves a single log of the authenticated user",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "exercise-logs"
+                ],
+                "summary": "Get exercise log by ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Log ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dto.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.ExerciseLogDetailResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Replaces the values of a log, keeping its timestamp unless created_at is sent; the change is kept in the log history",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "exercise-logs"
+                ],
+                "summary": "Replace an exercise log",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Log ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Log values",
+                        "name": "log",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.ExerciseLogUpdateRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dto.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.ExerciseLogDetailResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Deletes an exercise log by its ID",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "exercise-logs"
+                ],
+                "summary": "Delete an exercise log",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Log ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Updates only the provided fields of a log; the change is kept in the log history",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "exercise-logs"
+                ],
+                "summary": "Partially update an exercise log",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Log ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Fields to update",
+                        "name": "log",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.ExerciseLogPatchRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dto.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.ExerciseLogDetailResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/logs/{id}/history": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Lists every edit made to a log, oldest first, with old and new values of the changed fields",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "exercise-logs"
+                ],
+                "summary": "Get the change history of a log",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Log ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dto.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/dto.ExerciseLogRevisionResponse"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/programs": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieves all programs owned by the authenticated user",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "programs"
+                ],
+                "summary": "Get all programs",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dto.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/dto.ProgramResponse"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Creates a multi-week program whose days reference the user's workouts",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "programs"
+                ],
+                "summary": "Create a training program",
+                "parameters": [
+                    {
+                        "description": "Program payload",
+                        "name": "program",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProgramCreateRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dto.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.ProgramResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/programs/today": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Derives today's workout from the active program enrollment, with the week's modifiers applied",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "programs"
+                ],
+                "summary": "Get today's workout",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dto.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.TodayWorkoutResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/programs/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieves a program with its weeks and days",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "programs"
+                ],
+                "summary": "Get program by ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Program ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dto.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.ProgramResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/programs/{id}/enroll": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Enrolls the authenticated user into a program from a start date, replacing any active enrollment",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "programs"
+                ],
+                "summary": "Enroll into a program",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Program ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Enrollment payload",
+                        "name": "enrollment",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProgramEnrollRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dto.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.ProgramEnrollmentResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/records": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Returns the current personal records of every exercise (heaviest weight, most reps at a weight, best estimated 1RM and best volume) with the history of records beaten, most recent first. Records of exercises linked to the same catalog entry are shared across workouts.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "records"
+                ],
+                "summary": "List personal records",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Only records of this exercise",
+                        "name": "exercise_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "heaviest_weight, most_reps, estimated_1rm or best_volume",
+                        "name": "type",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dto.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/dto.ExerciseRecordsResponse"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/search": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Searches the user's workouts, exercises and log notes, returning ranked results with highlighted matches. Highlights are HTML-escaped text with the matches wrapped in \u003cmark\u003e.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "search"
+                ],
+                "summary": "Full-text search",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Search terms, supports quotes, OR and -exclusion",
+                        "name": "q",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Restrict to one result type (workout, exercise, log)",
+                        "name": "type",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Max results, defaults to 20, at most 100",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dto.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/dto.SearchResult"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/sessions": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Starts a training session for one of the user's workouts",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "sessions"
+                ],
+                "summary": "Start a workout session",
+                "parameters": [
+                    {
+                        "description": "Session payload",
+                        "name": "session",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.WorkoutSessionStartRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dto.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.WorkoutSessionResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/sessions/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Returns duration, total volume and completed vs planned exercises of a session",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "sessions"
+                ],
+                "summary": "Get session summary",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Session ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dto.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.WorkoutSessionSummaryResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/sessions/{id}/finish": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Marks the session as finished and returns its summary",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "sessions"
+                ],
+                "summary": "Finish a workout session",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Session ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dto.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.WorkoutSessionSummaryResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/sync": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Returns the workouts, exercises and logs created, updated or deleted since the cursor, oldest change first, each with its version. Deleted entities come back as tombstones; exercises and logs of a deleted workout or exercise may only be removed together with it. Entities are returned in their current state, once even when they changed several times. Start without a cursor for a full sync and keep the returned cursor; pull again while has_more is true.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "sync"
+                ],
+                "summary": "Pull changes for offline sync",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "cursor of the previous pull, omit for a full sync",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Changes per page, defaults to 500, at most 1000",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Unit of log weights, kg or lb, defaults to the preferred unit",
+                        "name": "weight_unit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dto.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.SyncPullResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Applies operations made offline in the order sent, each on its own, so one failing does not undo the others. Entities created offline carry a client-generated UUID (client_id) that later operations, and exercise_client_id / workout_client_id references, can use before the server ID is known; repeating a create with the same client_id returns the existing entity. Conflicts: an update applies only if the entity has not changed on the server since base_version, otherwise the server copy wins and the operation reports a conflict with the server version; deletes win over updates and apply regardless of base_version; updating an entity deleted on the server, or creating under a deleted workout or exercise, is not applied. Every operation gets a result: applied, conflict or rejected. Pull afterwards to get the server state.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "sync"
+                ],
+                "summary": "Push changes made offline",
+                "parameters": [
+                    {
+                        "description": "Operations, at most 100",
+                        "name": "changes",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.SyncPushRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dto.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.SyncPushResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/taxonomy": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieves the muscle groups, equipment and movement patterns usable to tag and filter exercises",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "catalog"
+                ],
+                "summary": "List the exercise taxonomy",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dto.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.TaxonomyResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/workouts": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieves all workouts for the authenticated user",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "workouts"
+                ],
+                "summary": "Get all workouts",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dto.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/dto.WorkoutResponse"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Creates a workout with name and description",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "workouts"
+                ],
+                "summary": "Create a new workout",
+                "parameters": [
+                    {
+                        "description": "Workout data",
+                        "name": "workout",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.CreateOrUpdateWorkoutRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dto.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.WorkoutResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/workouts/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieves a specific workout with exercises",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "workouts"
+                ],
+                "summary": "Get workout by ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Workout ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.WorkoutByIDResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Updates workout name and description",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "workouts"
+                ],
+                "summary": "Update a workout",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Workout ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Updated workout data",
+                        "name": "workout",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.CreateOrUpdateWorkoutRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dto.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.WorkoutDTO"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Deletes a workout and all associated exercises and logs",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "workouts"
+                ],
+                "summary": "Delete a workout",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Workout ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dto.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.WorkoutDTO"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/workouts/{id}/exercises": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieves all exercises of a workout owned by the authenticated user",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "exercises"
+                ],
+                "summary": "List exercises of a workout",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Workout ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dto.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/dto.ExerciseResponse"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/workouts/{id}/groups": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Creates a superset, circuit or giant set out of exercises belonging to the workout",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "workouts"
+                ],
+                "summary": "Group exercises of a workout",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Workout ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Group payload",
+                        "name": "group",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.ExerciseGroupCreateRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dto.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.ExerciseGroupResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/workouts/{id}/groups/{groupId}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Deletes an exercise group; its exercises stay in the workout as standalone exercises",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "workouts"
+                ],
+                "summary": "Ungroup exercises",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Workout ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Group ID",
+                        "name": "groupId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/media/{id}": {
+            "get": {
+                "description": "Streams a media file or its thumbnail; access is granted by the signed URL, no token needed",
+                "produces": [
+                    "application/octet-stream"
+                ],
+                "tags": [
+                    "media"
+                ],
+                "summary": "Download media",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Media ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Expiry (unix seconds)",
+                        "name": "expires",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Signature",
+                        "name": "sig",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/media/{id}/thumbnail": {
+            "get": {
+                "description": "Streams a media file or its thumbnail; access is granted by the signed URL, no token needed",
+                "produces": [
+                    "application/octet-stream"
+                ],
+                "tags": [
+                    "media"
+                ],
+                "summary": "Download media",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Media ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Expiry (unix seconds)",
+                        "name": "expires",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Signature",
+                        "name": "sig",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/users": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Returns the authenticated user's data, BMI, and weight category",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Get authenticated user",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.SuccessResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/login": {
+            "post": {
+                "description": "Authenticates a user and returns JWT",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Login user",
+                "parameters": [
+                    {
+                        "description": "Login credentials",
+                        "name": "user",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.UserLoginRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/preferences": {
+            "patch": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Sets the preferred weight unit and the timezone; weights are stored in kg and returned in the preferred unit, days are counted in the timezone. Omitted fields are left unchanged.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Update user preferences",
+                "parameters": [
+                    {
+                        "description": "Preferences payload",
+                        "name": "preferences",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.UserPreferencesRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dto.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.UserPreferencesResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/register": {
+            "post": {
+                "description": "Creates a new user account",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Register a new user",
+                "parameters": [
+                    {
+                        "description": "Register payload",
+                        "name": "user",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.UserRegisterRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/dto.SuccessResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "dto.AdherenceResponse": {
+            "type": "object",
+            "properties": {
+                "adherence_percentage": {
+                    "description": "null when nothing was planned",
+                    "type": "number"
+                },
+                "completed": {
+                    "type": "integer"
+                },
+                "current_day_streak": {
+                    "description": "training days in a row",
+                    "type": "integer"
+                },
+                "current_week_streak": {
+                    "description": "weeks in a row reaching weekly_target",
+                    "type": "integer"
+                },
+                "from": {
+                    "description": "Adherence window",
+                    "type": "string"
+                },
+                "longest_day_streak": {
+                    "type": "integer"
+                },
+                "longest_week_streak": {
+                    "type": "integer"
+                },
+                "planned": {
+                    "type": "integer"
+                },
+                "program_id": {
+                    "description": "active program the schedule comes from",
+                    "type": "integer"
+                },
+                "rest_day_allowance": {
+                    "type": "integer"
+                },
+                "scheduled": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.ScheduledWorkoutResponse"
+                    }
+                },
+                "timezone": {
+                    "type": "string"
+                },
+                "to": {
+                    "type": "string"
+                },
+                "today": {
+                    "type": "string"
+                },
+                "training_days": {
+                    "type": "integer"
+                },
+                "weekly_target": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.CatalogEntryCreateRequest": {
+            "type": "object",
+            "required": [
+                "description",
+                "name"
+            ],
+            "properties": {
+                "description": {
+                    "type": "string"
+                },
+                "equipment": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "exercise_type": {
+                    "description": "defaults to strength",
+                    "type": "string"
+                },
+                "movement_pattern": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "primary_muscles": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "secondary_muscles": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "dto.CatalogEntryResponse": {
+            "type": "object",
+            "properties": {
+                "description": {
+                    "type": "string"
+                },
+                "equipment": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "exercise_type": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "movement_pattern": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "primary_muscles": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "secondary_muscles": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "system": {
+                    "type": "boolean"
+                },
+                "usage": {
+                    "$ref": "#/definitions/dto.CatalogUsage"
+                }
+            }
+        },
+        "dto.CatalogTaxonomyRequest": {
+            "type": "object",
+            "properties": {
+                "equipment": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "movement_pattern": {
+                    "type": "string"
+                },
+                "primary_muscles": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "secondary_muscles": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "dto.CatalogUsage": {
+            "type": "object",
+            "properties": {
+                "last_logged_at": {
+                    "type": "string"
+                },
+                "log_count": {
+                    "type": "integer"
+                },
+                "total_volume": {
+                    "type": "number"
+                },
+                "weight_unit": {
+                    "type": "string"
+                },
+                "workout_count": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.CreateOrUpdateWorkoutRequest": {
+            "type": "object",
+            "properties": {
+                "description": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.ErrorResponse": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string"
+                },
+                "details": {},
+                "message": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.ExerciseAlternativeResponse": {
+            "type": "object",
+            "properties": {
+                "description": {
+                    "type": "string"
+                },
+                "equipment": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "exercise_type": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "movement_pattern": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "primary_muscles": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "reasons": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "score": {
+                    "type": "integer"
+                },
+                "secondary_muscles": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "system": {
+                    "type": "boolean"
+                },
+                "usage": {
+                    "$ref": "#/definitions/dto.CatalogUsage"
+                }
+            }
+        },
+        "dto.ExerciseCreateRequest": {
+            "type": "object",
+            "required": [
+                "description",
+                "name",
+                "workout_id"
+            ],
+            "properties": {
+                "definition_id": {
+                    "description": "optional catalog entry, name/description default to it",
+                    "type": "integer"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "workout_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.ExerciseGroupCreateRequest": {
+            "type": "object",
+            "required": [
+                "exercise_ids",
+                "rounds",
+                "type"
+            ],
+            "properties": {
+                "exercise_ids": {
+                    "description": "in execution order",
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "name": {
+                    "type": "string"
+                },
+                "rest_seconds": {
+                    "type": "integer"
+                },
+                "rounds": {
+                    "type": "integer"
+                },
+                "type": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.ExerciseGroupItem": {
+            "type": "object",
+            "properties": {
+                "exercise_id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "order": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.ExerciseGroupResponse": {
+            "type": "object",
+            "properties": {
+                "exercises": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.ExerciseGroupItem"
+                    }
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "rest_seconds": {
+                    "type": "integer"
+                },
+                "rounds": {
+                    "type": "integer"
+                },
+                "type": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.ExerciseLogDetailResponse": {
+            "type": "object",
+            "properties": {
+                "added_weight": {
+                    "type": "number"
+                },
+                "assisted_weight": {
+                    "type": "number"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "definition_id": {
+                    "type": "integer"
+                },
+                "distance_meters": {
+                    "type": "integer"
+                },
+                "duration_seconds": {
+                    "type": "integer"
+                },
+                "exercise_id": {
+                    "type": "integer"
+                },
+                "exercise_name": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "notes": {
+                    "type": "string"
+                },
+                "pace_seconds_per_km": {
+                    "type": "integer"
+                },
+                "perceived_effort": {
+                    "type": "number"
+                },
+                "rep_count": {
+                    "type": "integer"
+                },
+                "rest_seconds": {
+                    "type": "integer"
+                },
+                "round": {
+                    "type": "integer"
+                },
+                "session_id": {
+                    "type": "integer"
+                },
+                "set_count": {
+                    "type": "integer"
+                },
+                "sets": {
+                    "description": "empty for aggregate-only logs",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.LogSetResponse"
+                    }
+                },
+                "tempo": {
+                    "type": "string"
+                },
+                "weight": {
+                    "type": "number"
+                },
+                "weight_unit": {
+                    "type": "string"
+                },
+                "workout_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.ExerciseLogPageResponse": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.ExerciseLogDetailResponse"
+                    }
+                },
+                "next_cursor": {
+                    "description": "empty on the last page",
+                    "type": "string"
+                }
+            }
+        },
+        "dto.ExerciseLogPatchRequest": {
+            "type": "object",
+            "properties": {
+                "added_weight": {
+                    "type": "number"
+                },
+                "assisted_weight": {
+                    "type": "number"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "distance_meters": {
+                    "type": "integer"
+                },
+                "duration_seconds": {
+                    "type": "integer"
+                },
+                "notes": {
+                    "type": "string"
+                },
+                "perceived_effort": {
+                    "type": "number"
+                },
+                "rep_count": {
+                    "type": "integer"
+                },
+                "rest_seconds": {
+                    "type": "integer"
+                },
+                "round": {
+                    "type": "integer"
+                },
+                "set_count": {
+                    "type": "integer"
+                },
+                "sets": {
+                    "description": "replaces the sets when present",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.LogSetRequest"
+                    }
+                },
+                "tempo": {
+                    "type": "string"
+                },
+                "weight": {
+                    "type": "number"
+                },
+                "weight_unit": {
+                    "description": "unit of the sent weights, defaults to the user's preferred unit",
+                    "type": "string"
+                }
+            }
+        },
+        "dto.ExerciseLogRequest": {
+            "type": "object",
+            "properties": {
+                "added_weight": {
+                    "type": "number"
+                },
+                "assisted_weight": {
+                    "type": "number"
+                },
+                "created_at": {
+                    "description": "optional, defaults to the time the log is received",
+                    "type": "string"
+                },
+                "distance_meters": {
+                    "type": "integer"
+                },
+                "duration_seconds": {
+                    "description": "Type specific fields, see the exercise type of the catalog entry",
+                    "type": "integer"
+                },
+                "exercise_id": {
+                    "type": "integer"
+                },
+                "notes": {
+                    "description": "Optional context for coaches reviewing the log",
+                    "type": "string"
+                },
+                "perceived_effort": {
+                    "description": "1-10",
+                    "type": "number"
+                },
+                "rep_count": {
+                    "type": "integer"
+                },
+                "rest_seconds": {
+                    "type": "integer"
+                },
+                "round": {
+                    "description": "optional, only for grouped exercises",
+                    "type": "integer"
+                },
+                "session_id": {
+                    "description": "optional, attaches the log to an active session",
+                    "type": "integer"
+                },
+                "set_count": {
+                    "type": "integer"
+                },
+                "sets": {
+                    "description": "Optional individual sets, set_count, rep_count and weight are derived from them",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.LogSetRequest"
+                    }
+                },
+                "tempo": {
+                    "description": "e.g. 3-1-1-0, X for explosive",
+                    "type": "string"
+                },
+                "weight": {
+                    "type": "number"
+                },
+                "weight_unit": {
+                    "description": "kg or lb, defaults to the user's preferred unit",
+                    "type": "string"
+                }
+            }
+        },
+        "dto.ExerciseLogResponse": {
+            "type": "object",
+            "properties": {
+                "added_weight": {
+                    "type": "number"
+                },
+                "assisted_weight": {
+                    "type": "number"
+                },
+                "distance_meters": {
+                    "type": "integer"
+                },
+                "duration_seconds": {
+                    "type": "integer"
+                },
+                "exercise_id": {
+                    "type": "integer"
+                },
+                "exercise_type": {
+                    "type": "string"
+                },
+                "is_personal_record": {
+                    "description": "Records beaten by this log, see GET /api/records",
+                    "type": "boolean"
+                },
+                "notes": {
+                    "type": "string"
+                },
+                "pace_seconds_per_km": {
+                    "type": "integer"
+                },
+                "perceived_effort": {
+                    "type": "number"
+                },
+                "personal_records": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.PersonalRecordResponse"
+                    }
+                },
+                "rep_count": {
+                    "type": "integer"
+                },
+                "rest_seconds": {
+                    "type": "integer"
+                },
+                "round": {
+                    "type": "integer"
+                },
+                "session_id": {
+                    "type": "integer"
+                },
+                "set_count": {
+                    "type": "integer"
+                },
+                "sets": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.LogSetResponse"
+                    }
+                },
+                "tempo": {
+                    "type": "string"
+                },
+                "weight": {
+                    "type": "number"
+                },
+                "weight_unit": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.ExerciseLogRevisionResponse": {
+            "type": "object",
+            "properties": {
+                "changes": {
+                    "description": "weights in kg",
+                    "type": "object"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "user_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.ExerciseLogUpdateRequest": {
+            "type": "object",
+            "properties": {
+                "added_weight": {
+                    "type": "number"
+                },
+                "assisted_weight": {
+                    "type": "number"
+                },
+                "created_at": {
+                    "description": "optional, keeps the original timestamp when omitted",
+                    "type": "string"
+                },
+                "distance_meters": {
+                    "type": "integer"
+                },
+                "duration_seconds": {
+                    "type": "integer"
+                },
+                "notes": {
+                    "type": "string"
+                },
+                "perceived_effort": {
+                    "type": "number"
+                },
+                "rep_count": {
+                    "type": "integer"
+                },
+                "rest_seconds": {
+                    "type": "integer"
+                },
+                "round": {
+                    "type": "integer"
+                },
+                "set_count": {
+                    "type": "integer"
+                },
+                "sets": {
+                    "description": "replaces the sets, the log keeps no sets when omitted",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.LogSetRequest"
+                    }
+                },
+                "tempo": {
+                    "type": "string"
+                },
+                "weight": {
+                    "type": "number"
+                },
+                "weight_unit": {
+                    "description": "kg or lb, defaults to the user's preferred unit",
+                    "type": "string"
+                }
+            }
+        },
+        "dto.ExerciseMediaResponse": {
+            "type": "object",
+            "properties": {
+                "content_type": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "exercise_id": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "kind": {
+                    "type": "string"
+                },
+                "original_name": {
+                    "type": "string"
+                },
+                "size_bytes": {
+                    "type": "integer"
+                },
+                "thumbnail_url": {
+                    "description": "signed, expires",
+                    "type": "string"
+                },
+                "url": {
+                    "description": "signed, expires",
+                    "type": "string"
+                }
+            }
+        },
+        "dto.ExercisePatchRequest": {
+            "type": "object",
+            "properties": {
+                "definition_id": {
+                    "description": "catalog entry, a rename re-resolves it by name otherwise",
+                    "type": "integer"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.ExerciseRecordsResponse": {
+            "type": "object",
+            "properties": {
+                "current": {
+                    "description": "best of every record type, and of every weight for most_reps",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.PersonalRecordResponse"
+                    }
+                },
+                "definition_id": {
+                    "type": "integer"
+                },
+                "exercise_id": {
+                    "description": "exercise of the latest record",
+                    "type": "integer"
+                },
+                "exercise_name": {
+                    "type": "string"
+                },
+                "history": {
+                    "description": "every record set, most recent first",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.PersonalRecordResponse"
+                    }
+                }
+            }
+        },
+        "dto.ExerciseResponse": {
+            "type": "object",
+            "properties": {
+                "definition_id": {
+                    "type": "integer"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "workout_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.ExerciseSwapRequest": {
+            "type": "object",
+            "required": [
+                "definition_id"
+            ],
+            "properties": {
+                "definition_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.ExerciseSwapResponse": {
+            "type": "object",
+            "properties": {
+                "exercise": {
+                    "$ref": "#/definitions/dto.ExerciseResponse"
+                },
+                "from_definition_id": {
+                    "type": "integer"
+                },
+                "logs_kept": {
+                    "type": "integer"
+                },
+                "to_definition_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.ExerciseUpdateRequest": {
+            "type": "object",
+            "required": [
+                "description",
+                "name"
+            ],
+            "properties": {
+                "definition_id": {
+                    "description": "optional catalog entry, a rename re-resolves it by name otherwise",
+                    "type": "integer"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.ImportLogsResponse": {
+            "type": "object",
+            "properties": {
+                "dry_run": {
+                    "type": "boolean"
+                },
+                "duplicates_skipped": {
+                    "description": "logs already recorded, app imports only",
+                    "type": "integer"
+                },
+                "errors": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.ImportRowError"
+                    }
+                },
+                "exercises_created": {
+                    "description": "would be created on a dry run",
+                    "type": "integer"
+                },
+                "logs_created": {
+                    "type": "integer"
+                },
+                "rows_invalid": {
+                    "type": "integer"
+                },
+                "rows_total": {
+                    "type": "integer"
+                },
+                "rows_valid": {
+                    "type": "integer"
+                },
+                "source": {
+                    "description": "csv, strong or hevy",
+                    "type": "string"
+                },
+                "workouts_created": {
+                    "description": "would be created on a dry run",
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.ImportRowError": {
+            "type": "object",
+            "properties": {
+                "errors": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "row": {
+                    "description": "line in the file, the header is line 1",
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.LogSetRequest": {
+            "type": "object",
+            "properties": {
+                "rep_count": {
+                    "type": "integer"
+                },
+                "rir": {
+                    "type": "integer"
+                },
+                "rpe": {
+                    "type": "number"
+                },
+                "set_type": {
+                    "description": "warmup, working (default), drop or failure",
+                    "type": "string"
+                },
+                "weight": {
+                    "description": "added weight for bodyweight exercises, in the weight_unit of the log",
+                    "type": "number"
+                }
+            }
+        },
+        "dto.LogSetResponse": {
+            "type": "object",
+            "properties": {
+                "position": {
+                    "type": "integer"
+                },
+                "rep_count": {
+                    "type": "integer"
+                },
+                "rir": {
+                    "type": "integer"
+                },
+                "rpe": {
+                    "type": "number"
+                },
+                "set_type": {
+                    "type": "string"
+                },
+                "weight": {
+                    "type": "number"
+                }
+            }
+        },
+        "dto.NextTargetResponse": {
+            "type": "object",
+            "properties": {
+                "based_on_logs": {
+                    "type": "integer"
+                },
+                "exercise_id": {
+                    "type": "integer"
+                },
+                "reasoning": {
+                    "type": "string"
+                },
+                "rep_count": {
+                    "type": "integer"
+                },
+                "set_count": {
+                    "type": "integer"
+                },
+                "strategy": {
+                    "type": "string"
+                },
+                "weight": {
+                    "type": "number"
+                },
+                "weight_unit": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.PersonalRecordResponse": {
+            "type": "object",
+            "properties": {
+                "achieved_at": {
+                    "type": "string"
+                },
+                "exercise_id": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "log_id": {
+                    "type": "integer"
+                },
+                "previous_value": {
+                    "description": "record that was beaten",
+                    "type": "number"
+                },
+                "record_type": {
+                    "description": "heaviest_weight, most_reps, estimated_1rm or best_volume",
+                    "type": "string"
+                },
+                "rep_count": {
+                    "description": "reps of the set, total reps for best_volume",
+                    "type": "integer"
+                },
+                "value": {
+                    "description": "in weight_unit, reps for most_reps",
+                    "type": "number"
+                },
+                "weight": {
+                    "description": "weight of the set, the weight most_reps is counted at",
+                    "type": "number"
+                },
+                "weight_unit": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.ProgramCreateRequest": {
+            "type": "object",
+            "required": [
+                "description",
+                "name",
+                "weeks"
+            ],
+            "properties": {
+                "description": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "weeks": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.ProgramWeekRequest"
+                    }
+                }
+            }
+        },
+        "dto.ProgramDayRequest": {
+            "type": "object",
+            "required": [
+                "day_number",
+                "workout_id"
+            ],
+            "properties": {
+                "day_number": {
+                    "description": "1-7",
+                    "type": "integer"
+                },
+                "workout_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.ProgramEnrollRequest": {
+            "type": "object",
+            "properties": {
+                "start_date": {
+                    "description": "YYYY-MM-DD, defaults to today",
+                    "type": "string"
+                }
+            }
+        },
+        "dto.ProgramEnrollmentResponse": {
+            "type": "object",
+            "properties": {
+                "active": {
+                    "type": "boolean"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "program_id": {
+                    "type": "integer"
+                },
+                "start_date": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.ProgramResponse": {
+            "type": "object",
+            "properties": {
+                "description": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "week_count": {
+                    "type": "integer"
+                },
+                "weeks": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.ProgramWeek"
+                    }
+                }
+            }
+        },
+        "dto.ProgramWeekRequest": {
+            "type": "object",
+            "required": [
+                "week_number"
+            ],
+            "properties": {
+                "days": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.ProgramDayRequest"
+                    }
+                },
+                "intensity_modifier": {
+                    "description": "defaults to 1",
+                    "type": "number"
+                },
+                "volume_modifier": {
+                    "description": "defaults to 1",
+                    "type": "number"
+                },
+                "week_number": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.ScheduledWorkoutResponse": {
+            "type": "object",
+            "properties": {
+                "completed": {
+                    "type": "boolean"
+                },
+                "date": {
+                    "description": "YYYY-MM-DD",
+                    "type": "string"
+                },
+                "workout_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.SearchResult": {
+            "type": "object",
+            "properties": {
+                "highlight": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "rank": {
+                    "type": "number"
+                },
+                "title": {
+                    "type": "string"
+                },
+                "type": {
+                    "description": "workout, exercise or log",
+                    "type": "string"
+                },
+                "workout_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.SuccessResponse": {
+            "type": "object",
+            "properties": {
+                "data": {},
                 "message": {
                     "type": "string"
+                }
+            }
+        },
+        "dto.SyncExerciseRequest": {
+            "type": "object",
+            "required": [
+                "description",
+                "name",
+                "workout_id"
+            ],
+            "properties": {
+                "definition_id": {
+                    "description": "optional catalog entry, name/description default to it",
+                    "type": "integer"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "workout_client_id": {
+                    "description": "instead of workout_id, for workouts created offline",
+                    "type": "string"
+                },
+                "workout_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.SyncExerciseResponse": {
+            "type": "object",
+            "properties": {
+                "client_id": {
+                    "type": "string"
+                },
+                "definition_id": {
+                    "type": "integer"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "group_id": {
+                    "type": "integer"
+                },
+                "group_order": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "version": {
+                    "type": "integer"
+                },
+                "workout_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.SyncLogRequest": {
+            "type": "object",
+            "properties": {
+                "added_weight": {
+                    "type": "number"
+                },
+                "assisted_weight": {
+                    "type": "number"
+                },
+                "created_at": {
+                    "description": "optional, defaults to the time the log is received",
+                    "type": "string"
+                },
+                "distance_meters": {
+                    "type": "integer"
+                },
+                "duration_seconds": {
+                    "description": "Type specific fields, see the exercise type of the catalog entry",
+                    "type": "integer"
+                },
+                "exercise_client_id": {
+                    "description": "instead of exercise_id, for exercises created offline",
+                    "type": "string"
+                },
+                "exercise_id": {
+                    "type": "integer"
+                },
+                "notes": {
+                    "description": "Optional context for coaches reviewing the log",
+                    "type": "string"
+                },
+                "perceived_effort": {
+                    "description": "1-10",
+                    "type": "number"
+                },
+                "rep_count": {
+                    "type": "integer"
+                },
+                "rest_seconds": {
+                    "type": "integer"
+                },
+                "round": {
+                    "description": "optional, only for grouped exercises",
+                    "type": "integer"
+                },
+                "session_id": {
+                    "description": "optional, attaches the log to an active session",
+                    "type": "integer"
+                },
+                "set_count": {
+                    "type": "integer"
+                },
+                "sets": {
+                    "description": "Optional individual sets, set_count, rep_count and weight are derived from them",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.LogSetRequest"
+                    }
+                },
+                "tempo": {
+                    "description": "e.g. 3-1-1-0, X for explosive",
+                    "type": "string"
+                },
+                "weight": {
+                    "type": "number"
+                },
+                "weight_unit": {
+                    "description": "kg or lb, defaults to the user's preferred unit",
+                    "type": "string"
+                }
+            }
+        },
+        "dto.SyncLogResponse": {
+            "type": "object",
+            "properties": {
+                "added_weight": {
+                    "type": "number"
+                },
+                "assisted_weight": {
+                    "type": "number"
+                },
+                "client_id": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "definition_id": {
+                    "type": "integer"
+                },
+                "distance_meters": {
+                    "type": "integer"
+                },
+                "duration_seconds": {
+                    "type": "integer"
+                },
+                "exercise_id": {
+                    "type": "integer"
+                },
+                "exercise_name": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "notes": {
+                    "type": "string"
+                },
+                "pace_seconds_per_km": {
+                    "type": "integer"
+                },
+                "perceived_effort": {
+                    "type": "number"
+                },
+                "rep_count": {
+                    "type": "integer"
+                },
+                "rest_seconds": {
+                    "type": "integer"
+                },
+                "round": {
+                    "type": "integer"
+                },
+                "session_id": {
+                    "type": "integer"
+                },
+                "set_count": {
+                    "type": "integer"
+                },
+                "sets": {
+                    "description": "empty for aggregate-only logs",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.LogSetResponse"
+                    }
+                },
+                "tempo": {
+                    "type": "string"
+                },
+                "version": {
+                    "type": "integer"
+                },
+                "weight": {
+                    "type": "number"
+                },
+                "weight_unit": {
+                    "type": "string"
+                },
+                "workout_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.SyncOperation": {
+            "type": "object",
+            "properties": {
+                "base_version": {
+                    "description": "version the client last pulled, required to update",
+                    "type": "integer"
+                },
+                "client_id": {
+                    "description": "UUID generated by the client, required to create, identifies the entity otherwise",
+                    "type": "string"
+                },
+                "entity": {
+                    "description": "workout, exercise or log",
+                    "type": "string"
+                },
+                "exercise": {
+                    "$ref": "#/definitions/dto.SyncExerciseRequest"
+                },
+                "id": {
+                    "description": "server ID of the entity to update or delete, instead of client_id",
+                    "type": "integer"
+                },
+                "log": {
+                    "$ref": "#/definitions/dto.SyncLogRequest"
+                },
+                "op": {
+                    "description": "create, update or delete",
+                    "type": "string"
+                },
+                "workout": {
+                    "description": "Values of the entity, the one matching entity is required to create and update",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/dto.CreateOrUpdateWorkoutRequest"
+                        }
+                    ]
+                }
+            }
+        },
+        "dto.SyncOperationResult": {
+            "type": "object",
+            "properties": {
+                "client_id": {
+                    "type": "string"
+                },
+                "deleted": {
+                    "description": "the entity is deleted on the server",
+                    "type": "boolean"
+                },
+                "entity": {
+                    "type": "string"
+                },
+                "error": {
+                    "description": "why the operation was not applied",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    ]
+                },
+                "id": {
+                    "description": "server ID of the entity",
+                    "type": "integer"
+                },
+                "index": {
+                    "description": "position of the operation in the request",
+                    "type": "integer"
+                },
+                "op": {
+                    "type": "string"
+                },
+                "status": {
+                    "description": "applied, conflict or rejected",
+                    "type": "string"
+                },
+                "version": {
+                    "description": "version of the entity after the operation, or the server one on conflict",
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.SyncPullResponse": {
+            "type": "object",
+            "properties": {
+                "cursor": {
+                    "description": "send it as cursor on the next pull",
+                    "type": "string"
+                },
+                "deleted": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.SyncTombstoneResponse"
+                    }
+                },
+                "exercises": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.SyncExerciseResponse"
+                    }
+                },
+                "has_more": {
+                    "description": "pull again right away, references may point to later pages",
+                    "type": "boolean"
+                },
+                "logs": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.SyncLogResponse"
+                    }
+                },
+                "weight_unit": {
+                    "type": "string"
+                },
+                "workouts": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.SyncWorkoutResponse"
+                    }
+                }
+            }
+        },
+        "dto.SyncPushRequest": {
+            "type": "object",
+            "properties": {
+                "operations": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.SyncOperation"
+                    }
+                }
+            }
+        },
+        "dto.SyncPushResponse": {
+            "type": "object",
+            "properties": {
+                "applied": {
+                    "type": "integer"
+                },
+                "conflicts": {
+                    "type": "integer"
+                },
+                "rejected": {
+                    "type": "integer"
+                },
+                "results": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.SyncOperationResult"
+                    }
+                }
+            }
+        },
+        "dto.SyncTombstoneResponse": {
+            "type": "object",
+            "properties": {
+                "client_id": {
+                    "type": "string"
+                },
+                "deleted_at": {
+                    "type": "string"
+                },
+                "entity": {
+                    "description": "workout, exercise or log",
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "version": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.SyncWorkoutResponse": {
+            "type": "object",
+            "properties": {
+                "client_id": {
+                    "description": "set for workouts created through sync",
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "version": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.TaxonomyResponse": {
+            "type": "object",
+            "properties": {
+                "equipment": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.Equipment"
+                    }
+                },
+                "exercise_types": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "movement_patterns": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "muscle_groups": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.MuscleGroup"
+                    }
+                }
+            }
+        },
+        "dto.TodayWorkoutResponse": {
+            "type": "object",
+            "properties": {
+                "completed": {
+                    "description": "program already finished",
+                    "type": "boolean"
+                },
+                "date": {
+                    "type": "string"
+                },
+                "day_number": {
+                    "type": "integer"
+                },
+                "intensity_modifier": {
+                    "type": "number"
+                },
+                "program_id": {
+                    "type": "integer"
+                },
+                "program_name": {
+                    "type": "string"
+                },
+                "rest_day": {
+                    "type": "boolean"
+                },
+                "volume_modifier": {
+                    "type": "number"
+                },
+                "week_number": {
+                    "type": "integer"
+                },
+                "workout": {
+                    "$ref": "#/definitions/dto.WorkoutByIDResponse"
+                }
+            }
+        },
+        "dto.UserLoginRequest": {
+            "type": "object",
+            "properties": {
+                "email": {
+                    "type": "string"
+                },
+                "password": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.UserPreferencesRequest": {
+            "type": "object",
+            "properties": {
+                "timezone": {
+                    "description": "IANA timezone such as Europe/Paris, unchanged when empty",
+                    "type": "string"
+                },
+                "weight_unit": {
+                    "description": "kg or lb, unchanged when empty",
+                    "type": "string"
+                }
+            }
+        },
+        "dto.UserPreferencesResponse": {
+            "type": "object",
+            "properties": {
+                "timezone": {
+                    "type": "string"
+                },
+                "weight_unit": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.UserRegisterRequest": {
+            "type": "object",
+            "required": [
+                "email",
+                "full_name",
+                "height",
+                "password",
+                "weight"
+            ],
+            "properties": {
+                "email": {
+                    "type": "string"
+                },
+                "full_name": {
+                    "type": "string"
+                },
+                "height": {
+                    "type": "integer"
+                },
+                "password": {
+                    "type": "string"
+                },
+                "timezone": {
+                    "description": "IANA timezone, defaults to UTC",
+                    "type": "string"
+                },
+                "weight": {
+                    "type": "number"
+                },
+                "weight_unit": {
+                    "description": "kg (default) or lb, also becomes the preferred unit",
+                    "type": "string"
+                }
+            }
+        },
+        "dto.VolumeAnalyticsResponse": {
+            "type": "object",
+            "properties": {
+                "buckets": {
+                    "description": "by period, then by tonnage",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.VolumeBucket"
+                    }
+                },
+                "group_by": {
+                    "description": "exercise or muscle",
+                    "type": "string"
+                },
+                "period": {
+                    "description": "day, week or month",
+                    "type": "string"
+                },
+                "timezone": {
+                    "type": "string"
+                },
+                "totals": {
+                    "description": "omitted for muscle groups, where logs count more than once",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/dto.VolumeTotals"
+                        }
+                    ]
+                },
+                "weight_unit": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.VolumeBucket": {
+            "type": "object",
+            "properties": {
+                "average_intensity": {
+                    "description": "tonnage per rep, in weight_unit",
+                    "type": "number"
+                },
+                "group_id": {
+                    "description": "catalog entry or muscle group",
+                    "type": "integer"
+                },
+                "group_name": {
+                    "description": "exercise or muscle group name",
+                    "type": "string"
+                },
+                "log_count": {
+                    "type": "integer"
+                },
+                "period": {
+                    "description": "first day of the period, YYYY-MM-DD",
+                    "type": "string"
+                },
+                "rep_count": {
+                    "type": "integer"
+                },
+                "set_count": {
+                    "description": "working sets",
+                    "type": "integer"
+                },
+                "tonnage": {
+                    "description": "sets x reps x weight, in weight_unit",
+                    "type": "number"
+                }
+            }
+        },
+        "dto.VolumeTotals": {
+            "type": "object",
+            "properties": {
+                "average_intensity": {
+                    "type": "number"
+                },
+                "log_count": {
+                    "type": "integer"
+                },
+                "rep_count": {
+                    "type": "integer"
+                },
+                "set_count": {
+                    "type": "integer"
+                },
+                "tonnage": {
+                    "type": "number"
+                }
+            }
+        },
+        "dto.WorkoutByIDResponse": {
+            "type": "object",
+            "properties": {
+                "description": {
+                    "type": "string"
+                },
+                "exercises": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.Exercise"
+                    }
+                },
+                "groups": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.ExerciseGroupResponse"
+                    }
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
                 },
-                "status": {
+                "user_id": {
                     "type": "integer"
                 }
             }
         },
-        "dto.ExerciseCreateRequest": {
+        "dto.WorkoutDTO": {
             "type": "object",
-            "required": [
-                "description",
-                "name",
-                "workout_id"
-            ],
             "properties": {
                 "description": {
                     "type": "string"
                 },
+                "id": {
+                    "type": "integer"
+                },
                 "name": {
                     "type": "string"
-                },
-                "workout_id": {
-                    "type": "integer"
                 }
             }
         },
-        "dto.ExerciseLogRequest": {
+        "dto.WorkoutResponse": {
             "type": "object",
             "properties": {
-                "created_at": {
+                "description": {
                     "type": "string"
                 },
-                "exercise_id": {
-                    "type": "integer"
-                },
-                "rep_count": {
+                "id": {
                     "type": "integer"
                 },
-                "set_count": {
-                    "type": "integer"
+                "name": {
+                    "type": "string"
                 },
-                "weight": {
+                "user_id": {
                     "type": "integer"
                 }
             }
         },
-        "dto.SuccessResponse": {
+        "dto.WorkoutSessionResponse": {
             "type": "object",
             "properties": {
-                "data": {},
-                "message": {
+                "ended_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "started_at": {
                     "type": "string"
+                },
+                "workout_id": {
+                    "type": "integer"
                 }
             }
         },
-        "dto.UserLoginRequest": {
+        "dto.WorkoutSessionStartRequest": {
             "type": "object",
+            "required": [
+                "workout_id"
+            ],
             "properties": {
-                "email": {
-                    "type": "string"
-                },
-                "password": {
-                    "type": "string"
+                "workout_id": {
+                    "type": "integer"
                 }
             }
         },
-        "dto.UserRegisterRequest": {
+        "dto.WorkoutSessionSummaryResponse": {
             "type": "object",
-            "required": [
-                "email",
-                "full_name",
-                "height",
-                "password",
-                "weight"
-            ],
             "properties": {
-                "email": {
-                    "type": "string"
+                "duration_seconds": {
+                    "type": "integer"
                 },
-                "full_name": {
+                "ended_at": {
                     "type": "string"
                 },
-                "height": {
+                "exercises_completed": {
                     "type": "integer"
                 },
-                "password": {
+                "exercises_planned": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "log_count": {
+                    "type": "integer"
+                },
+                "started_at": {
                     "type": "string"
                 },
-                "weight": {
+                "total_volume": {
+                    "description": "sum of sets x reps x weight",
+                    "type": "number"
+                },
+                "weight_unit": {
+                    "type": "string"
+                },
+                "workout_id": {
                     "type": "integer"
                 }
             }
         },
-        "dto.WorkoutByIDResponse": {
+        "helper.ExportRow": {
             "type": "object",
             "properties": {
-                "description": {
+                "added_weight": {
+                    "type": "number"
+                },
+                "assisted_weight": {
+                    "type": "number"
+                },
+                "distance_meters": {
+                    "type": "integer"
+                },
+                "duration_seconds": {
+                    "type": "integer"
+                },
+                "exercise_id": {
+                    "type": "integer"
+                },
+                "exercise_name": {
                     "type": "string"
                 },
-                "exercises": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/model.Exercise"
-                    }
+                "exercise_type": {
+                    "type": "string"
                 },
-                "id": {
+                "log_id": {
                     "type": "integer"
                 },
-                "name": {
+                "logged_at": {
                     "type": "string"
                 },
-                "user_id": {
+                "notes": {
+                    "type": "string"
+                },
+                "pace_seconds_per_km": {
                     "type": "integer"
-                }
-            }
-        },
-        "dto.WorkoutDTO": {
-            "type": "object",
-            "properties": {
-                "description": {
+                },
+                "perceived_effort": {
+                    "type": "number"
+                },
+                "rep_count": {
+                    "type": "integer"
+                },
+                "rest_seconds": {
+                    "type": "integer"
+                },
+                "session_id": {
+                    "type": "integer"
+                },
+                "set_count": {
+                    "type": "integer"
+                },
+                "tempo": {
                     "type": "string"
                 },
-                "id": {
+                "weight": {
+                    "type": "number"
+                },
+                "weight_unit": {
+                    "type": "string"
+                },
+                "workout_id": {
                     "type": "integer"
                 },
-                "name": {
+                "workout_name": {
                     "type": "string"
                 }
             }
         },
-        "dto.WorkoutResponse": {
+        "model.Equipment": {
             "type": "object",
             "properties": {
-                "description": {
-                    "type": "string"
-                },
                 "id": {
                     "type": "integer"
                 },
                 "name": {
                     "type": "string"
-                },
-                "user_id": {
-                    "type": "integer"
                 }
             }
         },
         "model.Exercise": {
             "type": "object",
             "properties": {
+                "definitionID": {
+                    "description": "FK to the ExerciseDefinition catalog",
+                    "type": "integer"
+                },
                 "description": {
                     "type": "string"
                 },
@@ -903,6 +6327,14 @@ const docTemplate = `{
                         "$ref": "#/definitions/model.ExerciseLog"
                     }
                 },
+                "groupID": {
+                    "description": "optional FK to ExerciseGroup",
+                    "type": "integer"
+                },
+                "groupOrder": {
+                    "description": "position inside the group",
+                    "type": "integer"
+                },
                 "id": {
                     "type": "integer"
                 },
@@ -917,12 +6349,56 @@ const docTemplate = `{
                 }
             }
         },
+        "model.ExerciseGroup": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "rest_seconds": {
+                    "description": "rest between rounds",
+                    "type": "integer"
+                },
+                "rounds": {
+                    "type": "integer"
+                },
+                "type": {
+                    "type": "string"
+                },
+                "workout_id": {
+                    "type": "integer"
+                }
+            }
+        },
         "model.ExerciseLog": {
             "type": "object",
             "properties": {
+                "addedWeight": {
+                    "description": "kg, bodyweight exercises with a vest or belt",
+                    "type": "number"
+                },
+                "assistedWeight": {
+                    "description": "kg, bodyweight exercises with band or machine assistance",
+                    "type": "number"
+                },
                 "created_at": {
                     "type": "string"
                 },
+                "definitionID": {
+                    "description": "catalog definition the exercise referenced when logged",
+                    "type": "integer"
+                },
+                "distanceMeters": {
+                    "description": "distance cardio",
+                    "type": "integer"
+                },
+                "durationSeconds": {
+                    "description": "Type specific fields, zero when not relevant for the exercise type",
+                    "type": "integer"
+                },
                 "exercise": {
                     "$ref": "#/definitions/model.Exercise"
                 },
@@ -933,12 +6409,47 @@ const docTemplate = `{
                 "id": {
                     "type": "integer"
                 },
+                "notes": {
+                    "description": "How the exercise went, all optional",
+                    "type": "string"
+                },
+                "paceSecondsPerKm": {
+                    "description": "distance cardio, derived from duration and distance",
+                    "type": "integer"
+                },
+                "perceivedEffort": {
+                    "description": "1-10 for the whole exercise, sets carry their own RPE",
+                    "type": "number"
+                },
                 "repCount": {
                     "type": "integer"
                 },
+                "restSeconds": {
+                    "description": "rest taken between sets",
+                    "type": "integer"
+                },
+                "round": {
+                    "description": "round number when the exercise is part of a group, 0 otherwise",
+                    "type": "integer"
+                },
+                "sessionID": {
+                    "description": "optional FK to WorkoutSession",
+                    "type": "integer"
+                },
                 "setCount": {
                     "type": "integer"
                 },
+                "sets": {
+                    "description": "empty for aggregate-only logs",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.LogSet"
+                    }
+                },
+                "tempo": {
+                    "description": "eccentric-pause-concentric-pause in seconds, e.g. 3-1-1-0",
+                    "type": "string"
+                },
                 "user": {
                     "$ref": "#/definitions/model.User"
                 },
@@ -947,6 +6458,99 @@ const docTemplate = `{
                     "type": "integer"
                 },
                 "weight": {
+                    "description": "kg",
+                    "type": "number"
+                }
+            }
+        },
+        "model.LogSet": {
+            "type": "object",
+            "properties": {
+                "exerciseLogID": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "position": {
+                    "description": "1-based order within the log",
+                    "type": "integer"
+                },
+                "repCount": {
+                    "type": "integer"
+                },
+                "rir": {
+                    "description": "reps in reserve",
+                    "type": "integer"
+                },
+                "rpe": {
+                    "description": "rate of perceived exertion, 1-10",
+                    "type": "number"
+                },
+                "setType": {
+                    "type": "string"
+                },
+                "weight": {
+                    "description": "kg, added weight for bodyweight exercises",
+                    "type": "number"
+                }
+            }
+        },
+        "model.MuscleGroup": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "region": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.ProgramDay": {
+            "type": "object",
+            "properties": {
+                "day_number": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "program_week_id": {
+                    "type": "integer"
+                },
+                "workout_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.ProgramWeek": {
+            "type": "object",
+            "properties": {
+                "days": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.ProgramDay"
+                    }
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "intensity_modifier": {
+                    "description": "multiplier on weight",
+                    "type": "number"
+                },
+                "program_id": {
+                    "type": "integer"
+                },
+                "volume_modifier": {
+                    "description": "multiplier on sets",
+                    "type": "number"
+                },
+                "week_number": {
                     "type": "integer"
                 }
             }
@@ -966,8 +6570,17 @@ const docTemplate = `{
                 "id": {
                     "type": "integer"
                 },
+                "timezone": {
+                    "description": "IANA timezone days are counted in",
+                    "type": "string"
+                },
                 "weight": {
-                    "type": "integer"
+                    "description": "body weight in kg",
+                    "type": "number"
+                },
+                "weight_unit": {
+                    "description": "preferred unit for weights in responses, kg or lb",
+                    "type": "string"
                 }
             }
         },
@@ -983,6 +6596,12 @@ const docTemplate = `{
                         "$ref": "#/definitions/model.Exercise"
                     }
                 },
+                "groups": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.ExerciseGroup"
+                    }
+                },
                 "id": {
                     "type": "integer"
                 },
@@ -4,11 +4,20 @@ import "time"
 
 // 📥 For user registration
 type UserRegisterRequest struct {
-	Email    string `json:"email" form:"email" validate:"required,email"`
-	FullName string `json:"full_name" form:"full_name" validate:"required"`
-	Password string `json:"password" form:"password" validate:"required"`
-	Weight   int    `json:"weight" form:"weight" validate:"required"`
-	Height   int    `json:"height" form:"height" validate:"required"`
+	Email    string  `json:"email" form:"email" validate:"required,email"`
+	FullName string  `json:"full_name" form:"full_name" validate:"required"`
+	Password string  `json:"password" form:"password" validate:"required"`
+	Weight   float64 `json:"weight" form:"weight" validate:"required"`
+	Height   int     `json:"height" form:"height" validate:"required"`
+
+	WeightUnit string `json:"weight_unit" form:"weight_unit"` // kg (default) or lb, also becomes the preferred unit
+	Timezone   string `json:"timezone" form:"timezone"`       // IANA timezone, defaults to UTC
+}
+
+// 📥 For updating the preferences of the authenticated user
+type UserPreferencesRequest struct {
+	WeightUnit string `json:"weight_unit" form:"weight_unit"` // kg or lb, unchanged when empty
+	Timezone   string `json:"timezone" form:"timezone"`       // IANA timezone such as Europe/Paris, unchanged when empty
 }
 
 // 📥 For creating a workout
@@ -20,9 +29,49 @@ type WorkoutCreateRequest struct {
 
 // 📥 For adding an exercise to a workout
 type ExerciseCreateRequest struct {
-	Name        string `json:"name" form:"name" validate:"required"`
-	Description string `json:"description" form:"description" validate:"required"`
-	WorkoutID   uint   `json:"workout_id" form:"workout_id" validate:"required"`
+	Name         string `json:"name" form:"name" validate:"required"`
+	Description  string `json:"description" form:"description" validate:"required"`
+	WorkoutID    uint   `json:"workout_id" form:"workout_id" validate:"required"`
+	DefinitionID uint   `json:"definition_id" form:"definition_id"` // optional catalog entry, name/description default to it
+}
+
+// 📥 For adding a custom entry to the exercise catalog
+type CatalogEntryCreateRequest struct {
+	Name         string `json:"name" form:"name" validate:"required"`
+	Description  string `json:"description" form:"description" validate:"required"`
+	ExerciseType string `json:"exercise_type" form:"exercise_type"` // defaults to strength
+	CatalogTaxonomyRequest
+}
+
+// 📥 For tagging a catalog exercise with muscle groups, movement pattern and equipment (by name)
+type CatalogTaxonomyRequest struct {
+	MovementPattern  string   `json:"movement_pattern" form:"movement_pattern"`
+	PrimaryMuscles   []string `json:"primary_muscles" form:"primary_muscles"`
+	SecondaryMuscles []string `json:"secondary_muscles" form:"secondary_muscles"`
+	Equipment        []string `json:"equipment" form:"equipment"`
+}
+
+// 📥 For replacing an exercise (PUT)
+type ExerciseUpdateRequest struct {
+	Name         string `json:"name" form:"name" validate:"required"`
+	Description  string `json:"description" form:"description" validate:"required"`
+	DefinitionID uint   `json:"definition_id" form:"definition_id"` // optional catalog entry, a rename re-resolves it by name otherwise
+}
+
+// 📥 For partially updating an exercise (PATCH), nil fields are left untouched
+type ExercisePatchRequest struct {
+	Name         *string `json:"name" form:"name"`
+	Description  *string `json:"description" form:"description"`
+	DefinitionID *uint   `json:"definition_id" form:"definition_id"` // catalog entry, a rename re-resolves it by name otherwise
+}
+
+// 📥 For grouping exercises of a workout (superset, circuit, giant set)
+type ExerciseGroupCreateRequest struct {
+	Name        string `json:"name" form:"name"`
+	Type        string `json:"type" form:"type" validate:"required"`
+	Rounds      int    `json:"rounds" form:"rounds" validate:"required"`
+	RestSeconds int    `json:"rest_seconds" form:"rest_seconds"`
+	ExerciseIDs []uint `json:"exercise_ids" form:"exercise_ids" validate:"required"` // in execution order
 }
 
 // 📥 For logging an exercise session
@@ -45,9 +94,140 @@ type CreateOrUpdateWorkoutRequest struct {
 }
 
 type ExerciseLogRequest struct {
-	ExerciseID uint      `json:"exercise_id"`
-	SetCount   int       `json:"set_count"`
-	RepCount   int       `json:"rep_count"`
-	Weight     int       `json:"weight"`
-	CreatedAt  time.Time `json:"created_at"`
+	ExerciseID uint    `json:"exercise_id"`
+	SessionID  uint    `json:"session_id"` // optional, attaches the log to an active session
+	SetCount   int     `json:"set_count"`
+	RepCount   int     `json:"rep_count"`
+	Weight     float64 `json:"weight"`
+	WeightUnit string  `json:"weight_unit"` // kg or lb, defaults to the user's preferred unit
+
+	// Type specific fields, see the exercise type of the catalog entry
+	DurationSeconds int     `json:"duration_seconds"`
+	DistanceMeters  int     `json:"distance_meters"`
+	AddedWeight     float64 `json:"added_weight"`
+	AssistedWeight  float64 `json:"assisted_weight"`
+
+	// Optional context for coaches reviewing the log
+	Notes           string   `json:"notes"`
+	Tempo           string   `json:"tempo"` // e.g. 3-1-1-0, X for explosive
+	RestSeconds     int      `json:"rest_seconds"`
+	PerceivedEffort *float64 `json:"perceived_effort"` // 1-10
+
+	Round     int       `json:"round"`      // optional, only for grouped exercises
+	CreatedAt time.Time `json:"created_at"` // optional, defaults to the time the log is received
+
+	// Optional individual sets, set_count, rep_count and weight are derived from them
+	Sets []LogSetRequest `json:"sets"`
+}
+
+// 📥 One set of an exercise log
+type LogSetRequest struct {
+	SetType  string   `json:"set_type"` // warmup, working (default), drop or failure
+	RepCount int      `json:"rep_count"`
+	Weight   float64  `json:"weight"` // added weight for bodyweight exercises, in the weight_unit of the log
+	RPE      *float64 `json:"rpe"`
+	RIR      *int     `json:"rir"`
+}
+
+// 📥 For starting a workout session
+type WorkoutSessionStartRequest struct {
+	WorkoutID uint `json:"workout_id" form:"workout_id" validate:"required"`
+}
+
+// 📥 For creating a multi-week program
+type ProgramCreateRequest struct {
+	Name        string               `json:"name" form:"name" validate:"required"`
+	Description string               `json:"description" form:"description" validate:"required"`
+	Weeks       []ProgramWeekRequest `json:"weeks" form:"weeks" validate:"required"`
+}
+
+type ProgramWeekRequest struct {
+	WeekNumber        int                 `json:"week_number" validate:"required"`
+	IntensityModifier float64             `json:"intensity_modifier"` // defaults to 1
+	VolumeModifier    float64             `json:"volume_modifier"`    // defaults to 1
+	Days              []ProgramDayRequest `json:"days"`
+}
+
+type ProgramDayRequest struct {
+	DayNumber int  `json:"day_number" validate:"required"` // 1-7
+	WorkoutID uint `json:"workout_id" validate:"required"`
+}
+
+// 📥 For enrolling into a program
+type ProgramEnrollRequest struct {
+	StartDate string `json:"start_date" form:"start_date"` // YYYY-MM-DD, defaults to today
+}
+
+// 📥 For swapping a workout exercise to another catalog exercise
+type ExerciseSwapRequest struct {
+	DefinitionID uint `json:"definition_id" form:"definition_id" validate:"required"`
+}
+
+// 📥 For replacing the values of an exercise log (PUT)
+type ExerciseLogUpdateRequest struct {
+	SetCount        int             `json:"set_count"`
+	RepCount        int             `json:"rep_count"`
+	Weight          float64         `json:"weight"`
+	WeightUnit      string          `json:"weight_unit"` // kg or lb, defaults to the user's preferred unit
+	DurationSeconds int             `json:"duration_seconds"`
+	DistanceMeters  int             `json:"distance_meters"`
+	AddedWeight     float64         `json:"added_weight"`
+	AssistedWeight  float64         `json:"assisted_weight"`
+	Notes           string          `json:"notes"`
+	Tempo           string          `json:"tempo"`
+	RestSeconds     int             `json:"rest_seconds"`
+	PerceivedEffort *float64        `json:"perceived_effort"`
+	Round           int             `json:"round"`
+	CreatedAt       time.Time       `json:"created_at"` // optional, keeps the original timestamp when omitted
+	Sets            []LogSetRequest `json:"sets"`       // replaces the sets, the log keeps no sets when omitted
+}
+
+// 📥 For partially updating an exercise log (PATCH), nil fields are left untouched
+type ExerciseLogPatchRequest struct {
+	SetCount        *int            `json:"set_count"`
+	RepCount        *int            `json:"rep_count"`
+	Weight          *float64        `json:"weight"`
+	WeightUnit      string          `json:"weight_unit"` // unit of the sent weights, defaults to the user's preferred unit
+	DurationSeconds *int            `json:"duration_seconds"`
+	DistanceMeters  *int            `json:"distance_meters"`
+	AddedWeight     *float64        `json:"added_weight"`
+	AssistedWeight  *float64        `json:"assisted_weight"`
+	Notes           *string         `json:"notes"`
+	Tempo           *string         `json:"tempo"`
+	RestSeconds     *int            `json:"rest_seconds"`
+	PerceivedEffort *float64        `json:"perceived_effort"`
+	Round           *int            `json:"round"`
+	CreatedAt       *time.Time      `json:"created_at"`
+	Sets            []LogSetRequest `json:"sets"` // replaces the sets when present
+}
+
+// 📥 For pushing changes made offline, applied in the order sent
+type SyncPushRequest struct {
+	Operations []SyncOperation `json:"operations"`
+}
+
+// 📥 One change made offline
+type SyncOperation struct {
+	Op          string `json:"op"`           // create, update or delete
+	Entity      string `json:"entity"`       // workout, exercise or log
+	ClientID    string `json:"client_id"`    // UUID generated by the client, required to create, identifies the entity otherwise
+	ID          uint   `json:"id"`           // server ID of the entity to update or delete, instead of client_id
+	BaseVersion int64  `json:"base_version"` // version the client last pulled, required to update
+
+	// Values of the entity, the one matching entity is required to create and update
+	Workout  *CreateOrUpdateWorkoutRequest `json:"workout,omitempty"`
+	Exercise *SyncExerciseRequest          `json:"exercise,omitempty"`
+	Log      *SyncLogRequest               `json:"log,omitempty"`
+}
+
+// 📥 An exercise in a sync operation, only name, description and definition_id can be updated
+type SyncExerciseRequest struct {
+	ExerciseCreateRequest
+	WorkoutClientID string `json:"workout_client_id"` // instead of workout_id, for workouts created offline
+}
+
+// 📥 A log in a sync operation, its exercise and session cannot be updated
+type SyncLogRequest struct {
+	ExerciseLogRequest
+	ExerciseClientID string `json:"exercise_client_id"` // instead of exercise_id, for exercises created offline
 }
@@ -1,6 +1,10 @@
 package dto
 
-import "p2gc3/model"
+import (
+	"encoding/json"
+	"p2gc3/model"
+	"time"
+)
 
 type SuccessResponse struct {
 	Message string      `json:"message"`
@@ -8,11 +12,11 @@ type SuccessResponse struct {
 }
 
 type UserResponse struct {
-	ID       uint   `json:"id"`
-	Email    string `json:"email"`
-	FullName string `json:"full_name"`
-	Weight   int    `json:"weight"`
-	Height   int    `json:"height"`
+	ID       uint    `json:"id"`
+	Email    string  `json:"email"`
+	FullName string  `json:"full_name"`
+	Weight   float64 `json:"weight"`
+	Height   int     `json:"height"`
 }
 
 type WorkoutResponse struct {
@@ -22,11 +26,12 @@ type WorkoutResponse struct {
 	UserID      uint   `json:"user_id"`
 }
 type WorkoutByIDResponse struct {
-	ID          uint             `json:"id"`
-	Name        string           `json:"name"`
-	Description string           `json:"description"`
-	UserID      uint             `json:"user_id"`
-	Exercises   []model.Exercise `json:"exercises"`
+	ID          uint                    `json:"id"`
+	Name        string                  `json:"name"`
+	Description string                  `json:"description"`
+	UserID      uint                    `json:"user_id"`
+	Exercises   []model.Exercise        `json:"exercises"`
+	Groups      []ExerciseGroupResponse `json:"groups"`
 }
 
 type WorkoutPutDeleteResponse struct {
@@ -41,23 +46,395 @@ type WorkoutDTO struct {
 }
 
 type ExerciseResponse struct {
-	Name        string `json:"name"`
-	Description string `json:"description"`
+	ID           uint   `json:"id"`
+	WorkoutID    uint   `json:"workout_id"`
+	DefinitionID *uint  `json:"definition_id"`
+	Name         string `json:"name"`
+	Description  string `json:"description"`
+}
+
+type ExerciseGroupItem struct {
+	ExerciseID uint   `json:"exercise_id"`
+	Name       string `json:"name"`
+	Order      int    `json:"order"`
+}
+
+type ExerciseGroupResponse struct {
+	ID          uint                `json:"id"`
+	Name        string              `json:"name"`
+	Type        string              `json:"type"`
+	Rounds      int                 `json:"rounds"`
+	RestSeconds int                 `json:"rest_seconds"`
+	Exercises   []ExerciseGroupItem `json:"exercises"`
 }
 
 type ExerciseLogResponse struct {
-	ExerciseID uint `json:"exercise_id"`
-	SetCount   uint `json:"set_count"`
-	RepCount   uint `json:"rep_count"`
-	Weight     uint `json:"weight"`
+	ExerciseID uint    `json:"exercise_id"`
+	SetCount   uint    `json:"set_count"`
+	RepCount   uint    `json:"rep_count"`
+	Weight     float64 `json:"weight"`
+	WeightUnit string  `json:"weight_unit"`
+	Round      uint    `json:"round,omitempty"`
+	SessionID  uint    `json:"session_id,omitempty"`
+
+	ExerciseType     string  `json:"exercise_type,omitempty"`
+	DurationSeconds  int     `json:"duration_seconds,omitempty"`
+	DistanceMeters   int     `json:"distance_meters,omitempty"`
+	PaceSecondsPerKm int     `json:"pace_seconds_per_km,omitempty"`
+	AddedWeight      float64 `json:"added_weight,omitempty"`
+	AssistedWeight   float64 `json:"assisted_weight,omitempty"`
+
+	Notes           string   `json:"notes,omitempty"`
+	Tempo           string   `json:"tempo,omitempty"`
+	RestSeconds     int      `json:"rest_seconds,omitempty"`
+	PerceivedEffort *float64 `json:"perceived_effort,omitempty"`
+
+	Sets []LogSetResponse `json:"sets,omitempty"`
+
+	// Records beaten by this log, see GET /api/records
+	IsPersonalRecord bool                     `json:"is_personal_record"`
+	PersonalRecords  []PersonalRecordResponse `json:"personal_records"`
+}
+
+type LogSetResponse struct {
+	Position int      `json:"position"`
+	SetType  string   `json:"set_type"`
+	RepCount int      `json:"rep_count"`
+	Weight   float64  `json:"weight"`
+	RPE      *float64 `json:"rpe,omitempty"`
+	RIR      *int     `json:"rir,omitempty"`
 }
 
 type UserInfoWithBMIResponse struct {
 	ID             uint    `json:"id"`
 	Email          string  `json:"email"`
 	FullName       string  `json:"full_name"`
-	Weight         int     `json:"weight"`
+	Weight         float64 `json:"weight"`
+	WeightUnit     string  `json:"weight_unit"`
+	Timezone       string  `json:"timezone"`
 	Height         int     `json:"height"`
 	BMI            float64 `json:"bmi"`
 	WeightCategory string  `json:"weight_category"`
 }
+
+type UserPreferencesResponse struct {
+	WeightUnit string `json:"weight_unit"`
+	Timezone   string `json:"timezone"`
+}
+
+type WorkoutSessionResponse struct {
+	ID        uint       `json:"id"`
+	WorkoutID uint       `json:"workout_id"`
+	StartedAt time.Time  `json:"started_at"`
+	EndedAt   *time.Time `json:"ended_at"`
+}
+
+type WorkoutSessionSummaryResponse struct {
+	ID                 uint       `json:"id"`
+	WorkoutID          uint       `json:"workout_id"`
+	StartedAt          time.Time  `json:"started_at"`
+	EndedAt            *time.Time `json:"ended_at"`
+	DurationSeconds    int64      `json:"duration_seconds"`
+	TotalVolume        float64    `json:"total_volume"` // sum of sets x reps x weight
+	WeightUnit         string     `json:"weight_unit"`
+	LogCount           int        `json:"log_count"`
+	ExercisesCompleted int        `json:"exercises_completed"`
+	ExercisesPlanned   int        `json:"exercises_planned"`
+}
+
+type ProgramResponse struct {
+	ID          uint                `json:"id"`
+	Name        string              `json:"name"`
+	Description string              `json:"description"`
+	WeekCount   int                 `json:"week_count"`
+	Weeks       []model.ProgramWeek `json:"weeks,omitempty"`
+}
+
+type ProgramEnrollmentResponse struct {
+	ID        uint      `json:"id"`
+	ProgramID uint      `json:"program_id"`
+	StartDate time.Time `json:"start_date"`
+	Active    bool      `json:"active"`
+}
+
+type TodayWorkoutResponse struct {
+	ProgramID         uint                 `json:"program_id"`
+	ProgramName       string               `json:"program_name"`
+	Date              string               `json:"date"`
+	WeekNumber        int                  `json:"week_number"`
+	DayNumber         int                  `json:"day_number"`
+	RestDay           bool                 `json:"rest_day"`
+	Completed         bool                 `json:"completed"` // program already finished
+	IntensityModifier float64              `json:"intensity_modifier"`
+	VolumeModifier    float64              `json:"volume_modifier"`
+	Workout           *WorkoutByIDResponse `json:"workout,omitempty"`
+}
+
+type NextTargetResponse struct {
+	ExerciseID uint    `json:"exercise_id"`
+	Strategy   string  `json:"strategy"`
+	SetCount   int     `json:"set_count"`
+	RepCount   int     `json:"rep_count"`
+	Weight     float64 `json:"weight"`
+	WeightUnit string  `json:"weight_unit"`
+	Reasoning  string  `json:"reasoning"`
+	BasedOn    int     `json:"based_on_logs"`
+}
+
+type CatalogUsage struct {
+	WorkoutCount int64      `json:"workout_count"`
+	LogCount     int64      `json:"log_count"`
+	TotalVolume  float64    `json:"total_volume"`
+	WeightUnit   string     `json:"weight_unit"`
+	LastLoggedAt *time.Time `json:"last_logged_at"`
+}
+
+type CatalogEntryResponse struct {
+	ID               uint          `json:"id"`
+	Name             string        `json:"name"`
+	Description      string        `json:"description"`
+	System           bool          `json:"system"`
+	ExerciseType     string        `json:"exercise_type"`
+	MovementPattern  string        `json:"movement_pattern"`
+	PrimaryMuscles   []string      `json:"primary_muscles"`
+	SecondaryMuscles []string      `json:"secondary_muscles"`
+	Equipment        []string      `json:"equipment"`
+	Usage            *CatalogUsage `json:"usage,omitempty"`
+}
+
+type TaxonomyResponse struct {
+	MuscleGroups     []model.MuscleGroup `json:"muscle_groups"`
+	Equipment        []model.Equipment   `json:"equipment"`
+	MovementPatterns []string            `json:"movement_patterns"`
+	ExerciseTypes    []string            `json:"exercise_types"`
+}
+
+type SearchResult struct {
+	Type      string  `json:"type"` // workout, exercise or log
+	ID        uint    `json:"id"`
+	WorkoutID uint    `json:"workout_id"`
+	Title     string  `json:"title"`
+	Highlight string  `json:"highlight"`
+	Rank      float64 `json:"rank"`
+}
+
+type ExerciseMediaResponse struct {
+	ID           uint      `json:"id"`
+	ExerciseID   uint      `json:"exercise_id"`
+	Kind         string    `json:"kind"`
+	ContentType  string    `json:"content_type"`
+	SizeBytes    int64     `json:"size_bytes"`
+	OriginalName string    `json:"original_name"`
+	URL          string    `json:"url"`                     // signed, expires
+	ThumbnailURL string    `json:"thumbnail_url,omitempty"` // signed, expires
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+type ExerciseAlternativeResponse struct {
+	CatalogEntryResponse
+	Score   int      `json:"score"`
+	Reasons []string `json:"reasons"`
+}
+
+type ExerciseSwapResponse struct {
+	Exercise         ExerciseResponse `json:"exercise"`
+	FromDefinitionID *uint            `json:"from_definition_id"`
+	ToDefinitionID   uint             `json:"to_definition_id"`
+	LogsKept         int64            `json:"logs_kept"`
+}
+
+type ExerciseLogDetailResponse struct {
+	ID               uint      `json:"id"`
+	ExerciseID       uint      `json:"exercise_id"`
+	ExerciseName     string    `json:"exercise_name"`
+	WorkoutID        uint      `json:"workout_id"`
+	DefinitionID     *uint     `json:"definition_id"`
+	SessionID        *uint     `json:"session_id"`
+	SetCount         int       `json:"set_count"`
+	RepCount         int       `json:"rep_count"`
+	Weight           float64   `json:"weight"`
+	WeightUnit       string    `json:"weight_unit"`
+	DurationSeconds  int       `json:"duration_seconds"`
+	DistanceMeters   int       `json:"distance_meters"`
+	PaceSecondsPerKm int       `json:"pace_seconds_per_km"`
+	AddedWeight      float64   `json:"added_weight"`
+	AssistedWeight   float64   `json:"assisted_weight"`
+	Notes            string    `json:"notes"`
+	Tempo            string    `json:"tempo"`
+	RestSeconds      int       `json:"rest_seconds"`
+	PerceivedEffort  *float64  `json:"perceived_effort"`
+	Round            int       `json:"round"`
+	CreatedAt        time.Time `json:"created_at"`
+
+	Sets []LogSetResponse `json:"sets"` // empty for aggregate-only logs
+}
+
+type ExerciseLogPageResponse struct {
+	Items      []ExerciseLogDetailResponse `json:"items"`
+	NextCursor string                      `json:"next_cursor,omitempty"` // empty on the last page
+}
+
+type ExerciseLogRevisionResponse struct {
+	ID        uint            `json:"id"`
+	UserID    uint            `json:"user_id"`
+	Changes   json.RawMessage `json:"changes" swaggertype:"object"` // weights in kg
+	CreatedAt time.Time       `json:"created_at"`
+}
+
+type ImportRowError struct {
+	Row    int      `json:"row"` // line in the file, the header is line 1
+	Errors []string `json:"errors"`
+}
+
+type ImportLogsResponse struct {
+	Source            string           `json:"source"` // csv, strong or hevy
+	DryRun            bool             `json:"dry_run"`
+	RowsTotal         int              `json:"rows_total"`
+	RowsValid         int              `json:"rows_valid"`
+	RowsInvalid       int              `json:"rows_invalid"`
+	WorkoutsCreated   int              `json:"workouts_created"`  // would be created on a dry run
+	ExercisesCreated  int              `json:"exercises_created"` // would be created on a dry run
+	LogsCreated       int              `json:"logs_created"`
+	DuplicatesSkipped int              `json:"duplicates_skipped"` // logs already recorded, app imports only
+	Errors            []ImportRowError `json:"errors"`
+}
+
+type PersonalRecordResponse struct {
+	ID            uint      `json:"id"`
+	ExerciseID    uint      `json:"exercise_id"`
+	LogID         uint      `json:"log_id"`
+	RecordType    string    `json:"record_type"`              // heaviest_weight, most_reps, estimated_1rm or best_volume
+	Value         float64   `json:"value"`                    // in weight_unit, reps for most_reps
+	PreviousValue *float64  `json:"previous_value,omitempty"` // record that was beaten
+	Weight        float64   `json:"weight"`                   // weight of the set, the weight most_reps is counted at
+	RepCount      int       `json:"rep_count"`                // reps of the set, total reps for best_volume
+	WeightUnit    string    `json:"weight_unit"`
+	AchievedAt    time.Time `json:"achieved_at"`
+}
+
+type ExerciseRecordsResponse struct {
+	DefinitionID *uint                    `json:"definition_id,omitempty"`
+	ExerciseID   uint                     `json:"exercise_id"` // exercise of the latest record
+	ExerciseName string                   `json:"exercise_name"`
+	Current      []PersonalRecordResponse `json:"current"` // best of every record type, and of every weight for most_reps
+	History      []PersonalRecordResponse `json:"history"` // every record set, most recent first
+}
+
+type VolumeBucket struct {
+	Period           string  `json:"period"`               // first day of the period, YYYY-MM-DD
+	GroupID          *uint   `json:"group_id,omitempty"`   // catalog entry or muscle group
+	GroupName        string  `json:"group_name,omitempty"` // exercise or muscle group name
+	LogCount         int     `json:"log_count"`
+	SetCount         int     `json:"set_count"` // working sets
+	RepCount         int     `json:"rep_count"`
+	Tonnage          float64 `json:"tonnage"`           // sets x reps x weight, in weight_unit
+	AverageIntensity float64 `json:"average_intensity"` // tonnage per rep, in weight_unit
+}
+
+type VolumeTotals struct {
+	LogCount         int     `json:"log_count"`
+	SetCount         int     `json:"set_count"`
+	RepCount         int     `json:"rep_count"`
+	Tonnage          float64 `json:"tonnage"`
+	AverageIntensity float64 `json:"average_intensity"`
+}
+
+type VolumeAnalyticsResponse struct {
+	Period     string         `json:"period"`             // day, week or month
+	GroupBy    string         `json:"group_by,omitempty"` // exercise or muscle
+	Timezone   string         `json:"timezone"`
+	WeightUnit string         `json:"weight_unit"`
+	Buckets    []VolumeBucket `json:"buckets"`          // by period, then by tonnage
+	Totals     *VolumeTotals  `json:"totals,omitempty"` // omitted for muscle groups, where logs count more than once
+}
+
+type ScheduledWorkoutResponse struct {
+	Date      string `json:"date"` // YYYY-MM-DD
+	WorkoutID uint   `json:"workout_id"`
+	Completed bool   `json:"completed"`
+}
+
+type AdherenceResponse struct {
+	Timezone          string `json:"timezone"`
+	Today             string `json:"today"`
+	CurrentDayStreak  int    `json:"current_day_streak"` // training days in a row
+	LongestDayStreak  int    `json:"longest_day_streak"`
+	CurrentWeekStreak int    `json:"current_week_streak"` // weeks in a row reaching weekly_target
+	LongestWeekStreak int    `json:"longest_week_streak"`
+	RestDayAllowance  int    `json:"rest_day_allowance"`
+	WeeklyTarget      int    `json:"weekly_target"`
+
+	// Adherence window
+	From                string                     `json:"from"`
+	To                  string                     `json:"to"`
+	TrainingDays        int                        `json:"training_days"`
+	ProgramID           *uint                      `json:"program_id,omitempty"` // active program the schedule comes from
+	Planned             int                        `json:"planned"`
+	Completed           int                        `json:"completed"`
+	AdherencePercentage *float64                   `json:"adherence_percentage"` // null when nothing was planned
+	Scheduled           []ScheduledWorkoutResponse `json:"scheduled"`
+}
+
+type SyncWorkoutResponse struct {
+	ID          uint    `json:"id"`
+	ClientID    *string `json:"client_id"` // set for workouts created through sync
+	Version     int64   `json:"version"`
+	Name        string  `json:"name"`
+	Description string  `json:"description"`
+}
+
+type SyncExerciseResponse struct {
+	ID           uint    `json:"id"`
+	ClientID     *string `json:"client_id"`
+	Version      int64   `json:"version"`
+	WorkoutID    uint    `json:"workout_id"`
+	DefinitionID *uint   `json:"definition_id"`
+	GroupID      *uint   `json:"group_id"`
+	GroupOrder   int     `json:"group_order"`
+	Name         string  `json:"name"`
+	Description  string  `json:"description"`
+}
+
+type SyncLogResponse struct {
+	ExerciseLogDetailResponse
+	ClientID *string `json:"client_id"`
+	Version  int64   `json:"version"`
+}
+
+// Tombstone of a deleted entity
+type SyncTombstoneResponse struct {
+	Entity    string    `json:"entity"` // workout, exercise or log
+	ID        uint      `json:"id"`
+	ClientID  *string   `json:"client_id"`
+	Version   int64     `json:"version"`
+	DeletedAt time.Time `json:"deleted_at"`
+}
+
+type SyncPullResponse struct {
+	Cursor     string                  `json:"cursor"`   // send it as cursor on the next pull
+	HasMore    bool                    `json:"has_more"` // pull again right away, references may point to later pages
+	WeightUnit string                  `json:"weight_unit"`
+	Workouts   []SyncWorkoutResponse   `json:"workouts"`
+	Exercises  []SyncExerciseResponse  `json:"exercises"`
+	Logs       []SyncLogResponse       `json:"logs"`
+	Deleted    []SyncTombstoneResponse `json:"deleted"`
+}
+
+type SyncOperationResult struct {
+	Index    int            `json:"index"` // position of the operation in the request
+	Op       string         `json:"op"`
+	Entity   string         `json:"entity"`
+	ClientID string         `json:"client_id,omitempty"`
+	ID       uint           `json:"id,omitempty"`      // server ID of the entity
+	Version  int64          `json:"version,omitempty"` // version of the entity after the operation, or the server one on conflict
+	Status   string         `json:"status"`            // applied, conflict or rejected
+	Deleted  bool           `json:"deleted,omitempty"` // the entity is deleted on the server
+	Error    *ErrorResponse `json:"error,omitempty"`   // why the operation was not applied
+}
+
+type SyncPushResponse struct {
+	Applied   int                   `json:"applied"`
+	Conflicts int                   `json:"conflicts"`
+	Rejected  int                   `json:"rejected"`
+	Results   []SyncOperationResult `json:"results"`
+}
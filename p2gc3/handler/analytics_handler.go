@@ -0,0 +1,519 @@
+package handler
+
+import (
+	"errors"
+	"math"
+	"net/http"
+	"p2gc3/config"
+	"p2gc3/dto"
+	helper "p2gc3/helpers"
+	"p2gc3/model"
+	"strconv"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"gorm.io/gorm"
+)
+
+// analyticsPeriods are the supported buckets of date_trunc
+var analyticsPeriods = map[string]bool{"day": true, "week": true, "month": true}
+
+// Groupings of the volume analytics besides the whole period
+const (
+	volumeGroupExercise = "exercise"
+	volumeGroupMuscle   = "muscle"
+)
+
+// logSetTotals joins the working set totals of logs recorded per set, NULL for aggregate-only logs
+const logSetTotals = `LEFT JOIN LATERAL (
+	SELECT SUM(log_sets.rep_count) AS rep_count, SUM(log_sets.rep_count * log_sets.weight) AS tonnage
+	FROM log_sets
+	WHERE log_sets.exercise_log_id = exercise_logs.id AND log_sets.set_type <> 'warmup'
+) AS set_totals ON true`
+
+// Per log totals, from the individual sets when they were logged. The weight of bodyweight
+// exercises is the added weight.
+const (
+	logRepsExpr    = "COALESCE(set_totals.rep_count, exercise_logs.set_count * exercise_logs.rep_count)"
+	logTonnageExpr = "COALESCE(set_totals.tonnage, exercise_logs.set_count * exercise_logs.rep_count * (exercise_logs.weight + exercise_logs.added_weight))"
+)
+
+// analyticsLocation returns the timezone analytics bucket days in, the tz query param
+// overrides the timezone of the user
+func analyticsLocation(c echo.Context, userID uint) (*time.Location, error) {
+	name := c.QueryParam("tz")
+	if name == "" {
+		return userLocation(userID)
+	}
+	loc, err := helper.LoadTimezone(name)
+	if err != nil {
+		return nil, echo.NewHTTPError(http.StatusBadRequest, dto.ErrorResponse{
+			Message: "Invalid tz, expected an IANA timezone such as Europe/Paris",
+			Details: err.Error(),
+		})
+	}
+	return loc, nil
+}
+
+// applyLogTimeRange filters logs on the from and to query params, dates are days in loc
+func applyLogTimeRange(c echo.Context, query *gorm.DB, loc *time.Location) (*gorm.DB, error) {
+	if from := c.QueryParam("from"); from != "" {
+		t, _, err := parseTimeParam(from, loc)
+		if err != nil {
+			return nil, echo.NewHTTPError(http.StatusBadRequest, dto.ErrorResponse{
+				Message: "Invalid from, expected YYYY-MM-DD or RFC3339",
+				Details: err.Error(),
+			})
+		}
+		query = query.Where("exercise_logs.created_at >= ?", t)
+	}
+	if to := c.QueryParam("to"); to != "" {
+		t, dateOnly, err := parseTimeParam(to, loc)
+		if err != nil {
+			return nil, echo.NewHTTPError(http.StatusBadRequest, dto.ErrorResponse{
+				Message: "Invalid to, expected YYYY-MM-DD or RFC3339",
+				Details: err.Error(),
+			})
+		}
+		if dateOnly {
+			query = query.Where("exercise_logs.created_at < ?", t.AddDate(0, 0, 1))
+		} else {
+			query = query.Where("exercise_logs.created_at <= ?", t)
+		}
+	}
+	return query, nil
+}
+
+// volumeRow is one period, or one group of a period, of the volume analytics
+type volumeRow struct {
+	Period     string
+	BucketID   uint
+	BucketName string
+	LogCount   int
+	SetCount   int
+	RepCount   int
+	Tonnage    float64
+}
+
+// volumeQuery aggregates the user's logs per period in loc, split by exercise or muscle group when
+// groupBy is set. Groups get aliases no joined table has as a column: Postgres resolves GROUP BY
+// names to input columns before output aliases.
+func volumeQuery(db *gorm.DB, userID uint, period, groupBy string, loc *time.Location) *gorm.DB {
+	// Periods are truncated on the local time of the user, then formatted as their first day
+	periodExpr := "to_char(date_trunc(?, exercise_logs.created_at AT TIME ZONE ?), 'YYYY-MM-DD')"
+	selects := periodExpr + " AS period, COUNT(DISTINCT exercise_logs.id) AS log_count, " +
+		"SUM(exercise_logs.set_count) AS set_count, SUM(" + logRepsExpr + ") AS rep_count, SUM(" + logTonnageExpr + ") AS tonnage"
+	groups := "period"
+
+	query := db.Table("exercise_logs").
+		Joins(logSetTotals).
+		Where("exercise_logs.user_id = ?", userID)
+
+	switch groupBy {
+	case volumeGroupExercise:
+		// Exercises linked to the same catalog entry are one exercise across workouts
+		query = query.
+			Joins("JOIN exercises ON exercises.id = exercise_logs.exercise_id").
+			Joins("LEFT JOIN exercise_definitions ON exercise_definitions.id = exercise_logs.definition_id")
+		selects += ", COALESCE(exercise_logs.definition_id, 0) AS bucket_id, COALESCE(exercise_definitions.name, exercises.name) AS bucket_name"
+		groups += ", bucket_id, bucket_name"
+	case volumeGroupMuscle:
+		query = query.
+			Joins("JOIN exercise_definition_primary_muscles ON exercise_definition_primary_muscles.exercise_definition_id = exercise_logs.definition_id").
+			Joins("JOIN muscle_groups ON muscle_groups.id = exercise_definition_primary_muscles.muscle_group_id")
+		selects += ", muscle_groups.id AS bucket_id, muscle_groups.name AS bucket_name"
+		groups += ", bucket_id, bucket_name"
+	}
+
+	return query.Select(selects, period, loc.String()).
+		Group(groups).
+		Order("period, tonnage DESC")
+}
+
+// GetVolumeAnalytics godoc
+// @Summary      Training volume analytics
+// @Description  Aggregates tonnage (sets x reps x weight), working sets, reps and average intensity (tonnage per rep) of the user's logs per day, week or month, optionally split by exercise or primary muscle group. Logs with individual sets use their working sets. Periods start at midnight in tz; weeks start on Monday. A log counts fully towards each of its primary muscle groups.
+// @Tags         analytics
+// @Produce      json
+// @Param        period       query  string  false  "day, week (default) or month"
+// @Param        group_by     query  string  false  "exercise or muscle, whole period when omitted"
+// @Param        exercise_id  query  int     false  "Only logs of the catalog entry this exercise is linked to"
+// @Param        from         query  string  false  "Logged at or after (YYYY-MM-DD or RFC3339)"
+// @Param        to           query  string  false  "Logged at or before (YYYY-MM-DD inclusive or RFC3339)"
+// @Param        tz           query  string  false  "IANA timezone periods are computed in, defaults to the user's timezone"
+// @Success      200  {object}  dto.SuccessResponse{data=dto.VolumeAnalyticsResponse}
+// @Failure      400  {object}  dto.ErrorResponse
+// @Failure      401  {object}  dto.ErrorResponse
+// @Failure      403  {object}  dto.ErrorResponse
+// @Failure      404  {object}  dto.ErrorResponse
+// @Failure      500  {object}  dto.ErrorResponse
+// @Router       /api/analytics/volume [get]
+// @Security     BearerAuth
+func GetVolumeAnalytics(c echo.Context) error {
+	userID, err := helper.ExtractUserID(c)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusUnauthorized, dto.ErrorResponse{
+			Message: "Failed to extract user information",
+			Details: err.Error(),
+		})
+	}
+
+	period := c.QueryParam("period")
+	if period == "" {
+		period = "week"
+	}
+	if !analyticsPeriods[period] {
+		return echo.NewHTTPError(http.StatusBadRequest, dto.ErrorResponse{
+			Message: "Invalid period",
+			Details: "period must be one of day, week, month",
+		})
+	}
+
+	groupBy := c.QueryParam("group_by")
+	if groupBy != "" && groupBy != volumeGroupExercise && groupBy != volumeGroupMuscle {
+		return echo.NewHTTPError(http.StatusBadRequest, dto.ErrorResponse{
+			Message: "Invalid group_by",
+			Details: "group_by must be exercise or muscle",
+		})
+	}
+
+	var exerciseID uint
+	if err := echo.QueryParamsBinder(c).Uint("exercise_id", &exerciseID).BindError(); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, dto.ErrorResponse{
+			Message: "Invalid query parameters",
+			Details: err.Error(),
+		})
+	}
+
+	loc, err := analyticsLocation(c, userID)
+	if err != nil {
+		return err
+	}
+
+	query := volumeQuery(config.DB, userID, period, groupBy, loc)
+	if exerciseID != 0 {
+		var exercise model.Exercise
+		err := config.DB.Preload("Workout").First(&exercise, exerciseID).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return echo.NewHTTPError(http.StatusNotFound, dto.ErrorResponse{
+				Message: "Exercise not found",
+			})
+		} else if err != nil {
+			return echo.NewHTTPError(http.StatusInternalServerError, dto.ErrorResponse{
+				Message: "Failed to retrieve exercise",
+				Details: err.Error(),
+			})
+		}
+		if exercise.Workout.UserID != userID {
+			return echo.NewHTTPError(http.StatusForbidden, dto.ErrorResponse{
+				Message: "You are not authorized to access this exercise",
+			})
+		}
+		// Same movement as the exercise does now, logs from before a swap belong to the old one
+		query = config.HistoryScope(query, "exercise_logs", userID, exercise.DefinitionID, exercise.ID)
+	}
+
+	query, err = applyLogTimeRange(c, query, loc)
+	if err != nil {
+		return err
+	}
+
+	var rows []volumeRow
+	if err := query.Scan(&rows).Error; err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, dto.ErrorResponse{
+			Message: "Failed to compute volume",
+			Details: err.Error(),
+		})
+	}
+
+	unit, err := userWeightUnit(userID)
+	if err != nil {
+		return err
+	}
+
+	response := dto.VolumeAnalyticsResponse{
+		Period:     period,
+		GroupBy:    groupBy,
+		Timezone:   loc.String(),
+		WeightUnit: unit,
+		Buckets:    make([]dto.VolumeBucket, 0, len(rows)),
+	}
+	totals := dto.VolumeTotals{}
+	var tonnage float64
+	for _, r := range rows {
+		bucket := dto.VolumeBucket{
+			Period:    r.Period,
+			GroupName: r.BucketName,
+			LogCount:  r.LogCount,
+			SetCount:  r.SetCount,
+			RepCount:  r.RepCount,
+			Tonnage:   helper.FromKg(r.Tonnage, unit),
+		}
+		if groupBy != "" && r.BucketID != 0 {
+			id := r.BucketID
+			bucket.GroupID = &id
+		}
+		if r.RepCount > 0 {
+			bucket.AverageIntensity = helper.FromKg(r.Tonnage/float64(r.RepCount), unit)
+		}
+		response.Buckets = append(response.Buckets, bucket)
+
+		tonnage += r.Tonnage
+		totals.LogCount += r.LogCount
+		totals.SetCount += r.SetCount
+		totals.RepCount += r.RepCount
+	}
+
+	// A log counts towards each of its muscle groups, so muscle buckets do not add up
+	if groupBy != volumeGroupMuscle {
+		totals.Tonnage = helper.FromKg(tonnage, unit)
+		if totals.RepCount > 0 {
+			totals.AverageIntensity = helper.FromKg(tonnage/float64(totals.RepCount), unit)
+		}
+		response.Totals = &totals
+	}
+
+	return c.JSON(http.StatusOK, dto.SuccessResponse{
+		Message: "Volume analytics retrieved",
+		Data:    response,
+	})
+}
+
+// adherenceDefaultDays is the adherence window when from is not given, ending today
+const adherenceDefaultDays = 28
+
+// programSchedule is the timetable of an enrollment in day numbers
+type programSchedule struct {
+	Start, End int            // first and last day of the program, inclusive
+	Workouts   map[int][]uint // workouts scheduled per day, days without any are rest days
+}
+
+func (p programSchedule) restDay(day int) bool {
+	return day >= p.Start && day <= p.End && len(p.Workouts[day]) == 0
+}
+
+// dayStart returns midnight in loc of a helper.DayNumber
+func dayStart(day int, loc *time.Location) time.Time {
+	d := helper.DayFromNumber(day)
+	return time.Date(d.Year(), d.Month(), d.Day(), 0, 0, 0, 0, loc)
+}
+
+// scheduleOf lays the weeks and days of an enrolled program out from its start date in loc
+func scheduleOf(enrollment model.ProgramEnrollment, loc *time.Location) programSchedule {
+	schedule := programSchedule{
+		Start:    helper.DayNumber(enrollment.StartDate.In(loc)),
+		Workouts: map[int][]uint{},
+	}
+
+	lastWeek := 0
+	for _, w := range enrollment.Program.Weeks {
+		if w.WeekNumber > lastWeek {
+			lastWeek = w.WeekNumber
+		}
+		for _, d := range w.Days {
+			day := schedule.Start + (w.WeekNumber-1)*7 + d.DayNumber - 1
+			schedule.Workouts[day] = append(schedule.Workouts[day], d.WorkoutID)
+		}
+	}
+	schedule.End = schedule.Start + lastWeek*7 - 1
+	return schedule
+}
+
+// GetAdherenceAnalytics godoc
+// @Summary      Training streaks and adherence
+// @Description  Returns daily streaks (training days in a row) and weekly streaks (weeks in a row reaching weekly_target training days) from the logs, and the share of workouts scheduled by the active program that were done in the window. Rest days of the program never break a daily streak and rest_days unplanned days off in a row are allowed. A scheduled workout counts as done when one of its exercises was logged, or a session of it was started, that day.
+// @Tags         analytics
+// @Produce      json
+// @Param        rest_days      query  int     false  "Unplanned days off in a row that keep the daily streak, defaults to 1"
+// @Param        weekly_target  query  int     false  "Training days that make a week count, defaults to 1"
+// @Param        from           query  string  false  "First day of the adherence window (YYYY-MM-DD), defaults to 27 days before to"
+// @Param        to             query  string  false  "Last day of the adherence window (YYYY-MM-DD), defaults to today"
+// @Param        tz             query  string  false  "IANA timezone days are computed in, defaults to the user's timezone"
+// @Success      200  {object}  dto.SuccessResponse{data=dto.AdherenceResponse}
+// @Failure      400  {object}  dto.ErrorResponse
+// @Failure      401  {object}  dto.ErrorResponse
+// @Failure      500  {object}  dto.ErrorResponse
+// @Router       /api/analytics/adherence [get]
+// @Security     BearerAuth
+func GetAdherenceAnalytics(c echo.Context) error {
+	userID, err := helper.ExtractUserID(c)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusUnauthorized, dto.ErrorResponse{
+			Message: "Failed to extract user information",
+			Details: err.Error(),
+		})
+	}
+
+	cfg := helper.StreakConfig{RestAllowance: 1, WeeklyTarget: 1}
+	if err := echo.QueryParamsBinder(c).
+		Int("rest_days", &cfg.RestAllowance).
+		Int("weekly_target", &cfg.WeeklyTarget).
+		BindError(); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, dto.ErrorResponse{
+			Message: "Invalid query parameters",
+			Details: err.Error(),
+		})
+	}
+	if cfg.RestAllowance < 0 || cfg.WeeklyTarget < 1 || cfg.WeeklyTarget > 7 {
+		return echo.NewHTTPError(http.StatusBadRequest, dto.ErrorResponse{
+			Message: "Invalid input: rest_days cannot be negative and weekly_target must be between 1 and 7",
+		})
+	}
+
+	loc, err := analyticsLocation(c, userID)
+	if err != nil {
+		return err
+	}
+	today := helper.DayNumber(time.Now().In(loc))
+
+	windowEnd := today
+	if v := c.QueryParam("to"); v != "" {
+		t, err := time.ParseInLocation(dateLayout, v, loc)
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, dto.ErrorResponse{
+				Message: "Invalid to, expected YYYY-MM-DD",
+				Details: err.Error(),
+			})
+		}
+		windowEnd = helper.DayNumber(t)
+	}
+	windowStart := windowEnd - adherenceDefaultDays + 1
+	if v := c.QueryParam("from"); v != "" {
+		t, err := time.ParseInLocation(dateLayout, v, loc)
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, dto.ErrorResponse{
+				Message: "Invalid from, expected YYYY-MM-DD",
+				Details: err.Error(),
+			})
+		}
+		windowStart = helper.DayNumber(t)
+	}
+	if windowStart > windowEnd {
+		return echo.NewHTTPError(http.StatusBadRequest, dto.ErrorResponse{
+			Message: "Invalid input: from must not be after to",
+		})
+	}
+
+	var trainingDays []string
+	if err := config.DB.Raw("SELECT DISTINCT to_char(created_at AT TIME ZONE ?, 'YYYY-MM-DD') FROM exercise_logs WHERE user_id = ?",
+		loc.String(), userID).Scan(&trainingDays).Error; err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, dto.ErrorResponse{
+			Message: "Failed to fetch training days",
+			Details: err.Error(),
+		})
+	}
+
+	var days []int
+	for _, d := range trainingDays {
+		t, err := time.Parse(dateLayout, d)
+		if err != nil {
+			return echo.NewHTTPError(http.StatusInternalServerError, dto.ErrorResponse{
+				Message: "Failed to read training days",
+				Details: err.Error(),
+			})
+		}
+		days = append(days, helper.DayNumber(t))
+	}
+
+	var enrollment model.ProgramEnrollment
+	hasProgram := true
+	err = config.DB.
+		Preload("Program.Weeks.Days").
+		Where("user_id = ? AND active = ?", userID, true).
+		First(&enrollment).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		hasProgram = false
+	} else if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, dto.ErrorResponse{
+			Message: "Failed to retrieve enrollment",
+			Details: err.Error(),
+		})
+	}
+
+	var schedule programSchedule
+	if hasProgram {
+		schedule = scheduleOf(enrollment, loc)
+		cfg.PlannedRest = schedule.restDay
+	}
+
+	streaks := helper.ComputeStreaks(days, today, cfg)
+	response := dto.AdherenceResponse{
+		Timezone:          loc.String(),
+		Today:             helper.DayFromNumber(today).Format(dateLayout),
+		CurrentDayStreak:  streaks.CurrentDays,
+		LongestDayStreak:  streaks.LongestDays,
+		CurrentWeekStreak: streaks.CurrentWeeks,
+		LongestWeekStreak: streaks.LongestWeeks,
+		RestDayAllowance:  cfg.RestAllowance,
+		WeeklyTarget:      cfg.WeeklyTarget,
+		From:              helper.DayFromNumber(windowStart).Format(dateLayout),
+		To:                helper.DayFromNumber(windowEnd).Format(dateLayout),
+		Scheduled:         []dto.ScheduledWorkoutResponse{},
+	}
+	for _, d := range days {
+		if d >= windowStart && d <= windowEnd {
+			response.TrainingDays++
+		}
+	}
+
+	if hasProgram {
+		response.ProgramID = &enrollment.ProgramID
+
+		// Only days of the window that are over or in progress can be adhered to
+		from, to := max(windowStart, schedule.Start), min(windowEnd, schedule.End, today)
+		if from <= to {
+			rangeStart, rangeEnd := dayStart(from, loc), dayStart(to+1, loc)
+
+			// Workouts done per day: a log of one of their exercises or a session of them
+			var done []struct {
+				Day       string
+				WorkoutID uint
+			}
+			if err := config.DB.Raw(`SELECT to_char(exercise_logs.created_at AT TIME ZONE @tz, 'YYYY-MM-DD') AS day, exercises.workout_id
+				FROM exercise_logs JOIN exercises ON exercises.id = exercise_logs.exercise_id
+				WHERE exercise_logs.user_id = @user AND exercise_logs.created_at >= @from AND exercise_logs.created_at < @to
+				UNION
+				SELECT to_char(started_at AT TIME ZONE @tz, 'YYYY-MM-DD') AS day, workout_id
+				FROM workout_sessions
+				WHERE user_id = @user AND started_at >= @from AND started_at < @to`,
+				map[string]interface{}{"tz": loc.String(), "user": userID, "from": rangeStart, "to": rangeEnd}).
+				Scan(&done).Error; err != nil {
+				return echo.NewHTTPError(http.StatusInternalServerError, dto.ErrorResponse{
+					Message: "Failed to fetch completed workouts",
+					Details: err.Error(),
+				})
+			}
+
+			completed := map[string]bool{}
+			for _, d := range done {
+				completed[d.Day+"/"+strconv.FormatUint(uint64(d.WorkoutID), 10)] = true
+			}
+
+			for day := from; day <= to; day++ {
+				date := helper.DayFromNumber(day).Format(dateLayout)
+				for _, workoutID := range schedule.Workouts[day] {
+					isDone := completed[date+"/"+strconv.FormatUint(uint64(workoutID), 10)]
+					response.Scheduled = append(response.Scheduled, dto.ScheduledWorkoutResponse{
+						Date:      date,
+						WorkoutID: workoutID,
+						Completed: isDone,
+					})
+					response.Planned++
+					if isDone {
+						response.Completed++
+					}
+				}
+			}
+		}
+
+		if response.Planned > 0 {
+			percentage := math.Round(float64(response.Completed)/float64(response.Planned)*1000) / 10
+			response.AdherencePercentage = &percentage
+		}
+	}
+
+	return c.JSON(http.StatusOK, dto.SuccessResponse{
+		Message: "Adherence analytics retrieved",
+		Data:    response,
+	})
+}
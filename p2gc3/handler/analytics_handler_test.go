@@ -0,0 +1,101 @@
+package handler
+
+import (
+	"p2gc3/model"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/schema"
+)
+
+// dryRunDB builds SQL for the postgres dialect without connecting to a database
+func dryRunDB(t *testing.T) *gorm.DB {
+	t.Helper()
+	db, err := gorm.Open(postgres.New(postgres.Config{DSN: "host=localhost"}), &gorm.Config{
+		DryRun:               true,
+		DisableAutomaticPing: true,
+	})
+	if err != nil {
+		t.Fatalf("open dry run db: %v", err)
+	}
+	return db
+}
+
+// tableColumns returns the column names of the tables of models
+func tableColumns(t *testing.T, db *gorm.DB, models ...interface{}) map[string]bool {
+	t.Helper()
+	columns := map[string]bool{}
+	for _, m := range models {
+		s, err := schema.Parse(m, &sync.Map{}, db.NamingStrategy)
+		if err != nil {
+			t.Fatalf("parse schema: %v", err)
+		}
+		for _, f := range s.Fields {
+			if f.DBName != "" {
+				columns[f.DBName] = true
+			}
+		}
+	}
+	return columns
+}
+
+func TestVolumeQueryGroupsByOutputAliases(t *testing.T) {
+	db := dryRunDB(t)
+
+	// Columns of every table the volume query can join, plus the many2many join table
+	columns := tableColumns(t, db, &model.ExerciseLog{}, &model.LogSet{}, &model.Exercise{},
+		&model.ExerciseDefinition{}, &model.MuscleGroup{})
+	columns["exercise_definition_id"] = true
+	columns["muscle_group_id"] = true
+
+	tests := []struct {
+		groupBy string
+		groups  []string
+		joins   []string
+	}{
+		{"", []string{"period"}, nil},
+		{volumeGroupExercise, []string{"period", "bucket_id", "bucket_name"}, []string{"JOIN exercises", "LEFT JOIN exercise_definitions"}},
+		{volumeGroupMuscle, []string{"period", "bucket_id", "bucket_name"}, []string{"JOIN exercise_definition_primary_muscles", "JOIN muscle_groups"}},
+	}
+
+	for _, tt := range tests {
+		t.Run("group_by="+tt.groupBy, func(t *testing.T) {
+			var rows []volumeRow
+			sql := volumeQuery(db, 1, "week", tt.groupBy, time.UTC).Find(&rows).Statement.SQL.String()
+
+			start := strings.Index(sql, "GROUP BY ")
+			end := strings.Index(sql, " ORDER BY ")
+			if start < 0 || end < start {
+				t.Fatalf("no GROUP BY ... ORDER BY in %s", sql)
+			}
+			groups := strings.Split(sql[start+len("GROUP BY "):end], ",")
+			if len(groups) != len(tt.groups) {
+				t.Fatalf("GROUP BY %v, want %v", groups, tt.groups)
+			}
+
+			for i, g := range groups {
+				g = strings.Trim(g, `" `)
+				if g != tt.groups[i] {
+					t.Errorf("GROUP BY item %d = %q, want %q", i, g, tt.groups[i])
+				}
+				// Postgres would group by the input column instead of the selected expression
+				if columns[g] {
+					t.Errorf("GROUP BY %q is also a column of a joined table", g)
+				}
+				if !strings.Contains(sql, " AS "+g) {
+					t.Errorf("GROUP BY %q is not a selected alias", g)
+				}
+			}
+
+			for _, join := range tt.joins {
+				if !strings.Contains(sql, join) {
+					t.Errorf("missing %q in %s", join, sql)
+				}
+			}
+		})
+	}
+}
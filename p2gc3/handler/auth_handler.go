@@ -40,6 +40,26 @@ func Register(c echo.Context) error {
 		})
 	}
 
+	if req.WeightUnit == "" {
+		req.WeightUnit = helper.UnitKg
+	}
+	if !helper.IsWeightUnit(req.WeightUnit) {
+		return echo.NewHTTPError(http.StatusBadRequest, dto.ErrorResponse{
+			Message: "Invalid weight_unit",
+			Details: "weight_unit must be kg or lb",
+		})
+	}
+
+	if req.Timezone == "" {
+		req.Timezone = helper.DefaultTimezone
+	}
+	if _, err := helper.LoadTimezone(req.Timezone); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, dto.ErrorResponse{
+			Message: "Invalid timezone",
+			Details: err.Error(),
+		})
+	}
+
 	var existingUser model.User
 	if err := config.DB.Where("email = ?", req.Email).First(&existingUser).Error; err == nil {
 		return echo.NewHTTPError(http.StatusBadRequest, dto.ErrorResponse{
@@ -59,8 +79,11 @@ func Register(c echo.Context) error {
 		Email:    req.Email,
 		FullName: req.FullName,
 		Password: string(bytes),
-		Weight:   req.Weight,
+		Weight:   helper.ToKg(req.Weight, req.WeightUnit),
 		Height:   req.Height,
+
+		WeightUnit: req.WeightUnit,
+		Timezone:   req.Timezone,
 	}
 
 	if err := config.DB.Create(&User).Error; err != nil {
@@ -160,7 +183,12 @@ func UserInfo(c echo.Context) error {
 		})
 	}
 
-	// 3rd party BMI usage
+	unit := u.WeightUnit
+	if !helper.IsWeightUnit(unit) {
+		unit = helper.UnitKg
+	}
+
+	// 3rd party BMI usage, weights are stored in kg
 	bmi, category, err := helper.GetBMIAndCategory(u.Weight, u.Height)
 	if err != nil {
 		category = "Unknown"
@@ -172,7 +200,9 @@ func UserInfo(c echo.Context) error {
 			ID:             u.ID,
 			Email:          u.Email,
 			FullName:       u.FullName,
-			Weight:         u.Weight,
+			Weight:         helper.FromKg(u.Weight, unit),
+			WeightUnit:     unit,
+			Timezone:       u.Timezone,
 			Height:         u.Height,
 			BMI:            bmi,
 			WeightCategory: category,
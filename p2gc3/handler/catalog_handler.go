@@ -0,0 +1,525 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"p2gc3/config"
+	"p2gc3/dto"
+	helper "p2gc3/helpers"
+	"p2gc3/model"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"gorm.io/gorm"
+)
+
+func toCatalogEntryResponse(def model.ExerciseDefinition) dto.CatalogEntryResponse {
+	response := dto.CatalogEntryResponse{
+		ID:               def.ID,
+		Name:             def.Name,
+		Description:      def.Description,
+		System:           def.UserID == nil,
+		ExerciseType:     def.ExerciseType,
+		MovementPattern:  def.MovementPattern,
+		PrimaryMuscles:   []string{},
+		SecondaryMuscles: []string{},
+		Equipment:        []string{},
+	}
+	for _, m := range def.PrimaryMuscles {
+		response.PrimaryMuscles = append(response.PrimaryMuscles, m.Name)
+	}
+	for _, m := range def.SecondaryMuscles {
+		response.SecondaryMuscles = append(response.SecondaryMuscles, m.Name)
+	}
+	for _, e := range def.Equipment {
+		response.Equipment = append(response.Equipment, e.Name)
+	}
+	return response
+}
+
+func isExerciseType(exerciseType string) bool {
+	for _, t := range model.ExerciseTypes {
+		if t == exerciseType {
+			return true
+		}
+	}
+	return false
+}
+
+// withTaxonomy preloads the taxonomy associations of catalog definitions
+func withTaxonomy(db *gorm.DB) *gorm.DB {
+	return db.Preload("PrimaryMuscles").Preload("SecondaryMuscles").Preload("Equipment")
+}
+
+// resolveTaxonomy validates the taxonomy names of a request and loads the matching records
+func resolveTaxonomy(req dto.CatalogTaxonomyRequest) ([]model.MuscleGroup, []model.MuscleGroup, []model.Equipment, error) {
+	var primary, secondary []model.MuscleGroup
+	var equipment []model.Equipment
+
+	if req.MovementPattern != "" {
+		valid := false
+		for _, p := range model.MovementPatterns {
+			if p == req.MovementPattern {
+				valid = true
+				break
+			}
+		}
+		if !valid {
+			return nil, nil, nil, echo.NewHTTPError(http.StatusBadRequest, dto.ErrorResponse{
+				Message: "Invalid input: unknown movement pattern",
+				Details: model.MovementPatterns,
+			})
+		}
+	}
+
+	// load reports whether every distinct name matched a record, a name sent twice counts once
+	load := func(names []string, dest interface{}, kind string) (bool, error) {
+		seen := map[string]bool{}
+		distinct := make([]string, 0, len(names))
+		for _, name := range names {
+			if !seen[name] {
+				seen[name] = true
+				distinct = append(distinct, name)
+			}
+		}
+		if len(distinct) == 0 {
+			return true, nil
+		}
+
+		res := config.DB.Where("name IN ?", distinct).Find(dest)
+		if res.Error != nil {
+			return false, echo.NewHTTPError(http.StatusInternalServerError, dto.ErrorResponse{
+				Message: "Failed to load " + kind,
+				Details: res.Error.Error(),
+			})
+		}
+		return int(res.RowsAffected) == len(distinct), nil
+	}
+
+	found, err := load(req.PrimaryMuscles, &primary, "muscle groups")
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	if !found {
+		return nil, nil, nil, echo.NewHTTPError(http.StatusBadRequest, dto.ErrorResponse{
+			Message: "Invalid input: unknown primary muscle group",
+			Details: req.PrimaryMuscles,
+		})
+	}
+
+	found, err = load(req.SecondaryMuscles, &secondary, "muscle groups")
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	if !found {
+		return nil, nil, nil, echo.NewHTTPError(http.StatusBadRequest, dto.ErrorResponse{
+			Message: "Invalid input: unknown secondary muscle group",
+			Details: req.SecondaryMuscles,
+		})
+	}
+
+	found, err = load(req.Equipment, &equipment, "equipment")
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	if !found {
+		return nil, nil, nil, echo.NewHTTPError(http.StatusBadRequest, dto.ErrorResponse{
+			Message: "Invalid input: unknown equipment",
+			Details: req.Equipment,
+		})
+	}
+
+	return primary, secondary, equipment, nil
+}
+
+// findVisibleDefinition loads a catalog entry by the :id path param, system entries or the user's own
+func findVisibleDefinition(c echo.Context, userID uint) (model.ExerciseDefinition, error) {
+	var def model.ExerciseDefinition
+
+	defID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return def, echo.NewHTTPError(http.StatusBadRequest, dto.ErrorResponse{
+			Message: "Invalid catalog exercise ID",
+			Details: err.Error(),
+		})
+	}
+
+	err = withTaxonomy(config.DB).First(&def, defID).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return def, echo.NewHTTPError(http.StatusNotFound, dto.ErrorResponse{
+			Message: "Catalog exercise not found",
+		})
+	} else if err != nil {
+		return def, echo.NewHTTPError(http.StatusInternalServerError, dto.ErrorResponse{
+			Message: "Failed to retrieve catalog exercise",
+			Details: err.Error(),
+		})
+	}
+
+	if def.UserID != nil && *def.UserID != userID {
+		return def, echo.NewHTTPError(http.StatusForbidden, dto.ErrorResponse{
+			Message: "You are not authorized to view this catalog exercise",
+		})
+	}
+
+	return def, nil
+}
+
+// GetCatalog godoc
+// @Summary      List the exercise catalog
+// @Description  Retrieves system-provided exercises and the user's custom ones, filterable by taxonomy
+// @Tags         catalog
+// @Produce      json
+// @Param        q             query  string  false  "Filter by name"
+// @Param        muscle        query  string  false  "Filter by muscle group name"
+// @Param        primary_only  query  bool    false  "Only match the muscle group as primary mover"
+// @Param        region        query  string  false  "Filter by body region (upper, lower, core)"
+// @Param        equipment     query  string  false  "Filter by required equipment"
+// @Param        pattern       query  string  false  "Filter by movement pattern"
+// @Param        type          query  string  false  "Filter by exercise type"
+// @Success      200  {object}  dto.SuccessResponse{data=[]dto.CatalogEntryResponse}
+// @Failure      401  {object}  dto.ErrorResponse
+// @Failure      500  {object}  dto.ErrorResponse
+// @Router       /api/catalog [get]
+// @Security     BearerAuth
+func GetCatalog(c echo.Context) error {
+	userID, err := helper.ExtractUserID(c)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusUnauthorized, dto.ErrorResponse{
+			Message: "Failed to extract user information",
+			Details: err.Error(),
+		})
+	}
+
+	query := withTaxonomy(config.DB).Where("user_id IS NULL OR user_id = ?", userID)
+	if q := strings.TrimSpace(c.QueryParam("q")); q != "" {
+		query = query.Where("name ILIKE ?", "%"+q+"%")
+	}
+	if pattern := c.QueryParam("pattern"); pattern != "" {
+		query = query.Where("movement_pattern = ?", pattern)
+	}
+	if exerciseType := c.QueryParam("type"); exerciseType != "" {
+		query = query.Where("exercise_type = ?", exerciseType)
+	}
+
+	// Muscle filters match the primary join table, and the secondary one unless primary_only is set
+	primaryOnly := c.QueryParam("primary_only") == "true"
+	muscleFilter := func(column, value string) {
+		primary := config.DB.Table("exercise_definition_primary_muscles").
+			Select("exercise_definition_id").
+			Joins("JOIN muscle_groups ON muscle_groups.id = exercise_definition_primary_muscles.muscle_group_id").
+			Where("muscle_groups."+column+" = ?", value)
+		if primaryOnly {
+			query = query.Where("id IN (?)", primary)
+			return
+		}
+		secondary := config.DB.Table("exercise_definition_secondary_muscles").
+			Select("exercise_definition_id").
+			Joins("JOIN muscle_groups ON muscle_groups.id = exercise_definition_secondary_muscles.muscle_group_id").
+			Where("muscle_groups."+column+" = ?", value)
+		query = query.Where("id IN (?) OR id IN (?)", primary, secondary)
+	}
+	if muscle := c.QueryParam("muscle"); muscle != "" {
+		muscleFilter("name", muscle)
+	}
+	if region := c.QueryParam("region"); region != "" {
+		muscleFilter("region", region)
+	}
+
+	if equipment := c.QueryParam("equipment"); equipment != "" {
+		query = query.Where("id IN (?)", config.DB.Table("exercise_definition_equipment").
+			Select("exercise_definition_id").
+			Joins("JOIN equipment ON equipment.id = exercise_definition_equipment.equipment_id").
+			Where("equipment.name = ?", equipment))
+	}
+
+	var defs []model.ExerciseDefinition
+	if err := query.Order("name").Find(&defs).Error; err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, dto.ErrorResponse{
+			Message: "Failed to retrieve catalog",
+			Details: err.Error(),
+		})
+	}
+
+	response := []dto.CatalogEntryResponse{}
+	for _, def := range defs {
+		response = append(response, toCatalogEntryResponse(def))
+	}
+
+	return c.JSON(http.StatusOK, dto.SuccessResponse{
+		Message: "Success Retrieving Catalog",
+		Data:    response,
+	})
+}
+
+// CreateCatalogEntry godoc
+// @Summary      Add a custom catalog exercise
+// @Description  Adds a user-owned exercise definition to the catalog
+// @Tags         catalog
+// @Accept       json
+// @Produce      json
+// @Param        entry  body  dto.CatalogEntryCreateRequest  true  "Catalog entry payload"
+// @Success      201  {object}  dto.SuccessResponse{data=dto.CatalogEntryResponse}
+// @Failure      400  {object}  dto.ErrorResponse
+// @Failure      401  {object}  dto.ErrorResponse
+// @Failure      409  {object}  dto.ErrorResponse
+// @Failure      500  {object}  dto.ErrorResponse
+// @Router       /api/catalog [post]
+// @Security     BearerAuth
+func CreateCatalogEntry(c echo.Context) error {
+	userID, err := helper.ExtractUserID(c)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusUnauthorized, dto.ErrorResponse{
+			Message: "Failed to extract user information",
+			Details: err.Error(),
+		})
+	}
+
+	var req dto.CatalogEntryCreateRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, dto.ErrorResponse{
+			Message: "Invalid input",
+			Details: err.Error(),
+		})
+	}
+
+	if strings.TrimSpace(req.Name) == "" || req.Description == "" {
+		return echo.NewHTTPError(http.StatusBadRequest, dto.ErrorResponse{
+			Message: "Exercise name and description are required",
+		})
+	}
+
+	var count int64
+	if err := config.DB.Model(&model.ExerciseDefinition{}).
+		Where("(user_id IS NULL OR user_id = ?) AND LOWER(TRIM(name)) = LOWER(TRIM(?))", userID, req.Name).
+		Count(&count).Error; err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, dto.ErrorResponse{
+			Message: "Failed to check catalog",
+			Details: err.Error(),
+		})
+	}
+	if count > 0 {
+		return echo.NewHTTPError(http.StatusConflict, dto.ErrorResponse{
+			Message: "An exercise with this name already exists in the catalog",
+		})
+	}
+
+	if req.ExerciseType == "" {
+		req.ExerciseType = model.TypeStrength
+	}
+	if !isExerciseType(req.ExerciseType) {
+		return echo.NewHTTPError(http.StatusBadRequest, dto.ErrorResponse{
+			Message: "Invalid input: unknown exercise type",
+			Details: model.ExerciseTypes,
+		})
+	}
+
+	primary, secondary, equipment, err := resolveTaxonomy(req.CatalogTaxonomyRequest)
+	if err != nil {
+		return err
+	}
+
+	def := model.ExerciseDefinition{
+		UserID:           &userID,
+		Name:             strings.TrimSpace(req.Name),
+		Description:      req.Description,
+		ExerciseType:     req.ExerciseType,
+		MovementPattern:  req.MovementPattern,
+		PrimaryMuscles:   primary,
+		SecondaryMuscles: secondary,
+		Equipment:        equipment,
+	}
+
+	// Associations are only linked, the taxonomy itself is never created from here
+	if err := config.DB.Omit("PrimaryMuscles.*", "SecondaryMuscles.*", "Equipment.*").Create(&def).Error; err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, dto.ErrorResponse{
+			Message: "Failed to create catalog exercise",
+			Details: err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusCreated, dto.SuccessResponse{
+		Message: "Catalog exercise successfully created",
+		Data:    toCatalogEntryResponse(def),
+	})
+}
+
+// GetCatalogEntry godoc
+// @Summary      Get a catalog exercise
+// @Description  Retrieves a catalog exercise with the user's usage aggregated across all workouts
+// @Tags         catalog
+// @Produce      json
+// @Param        id  path  int  true  "Catalog exercise ID"
+// @Success      200  {object}  dto.SuccessResponse{data=dto.CatalogEntryResponse}
+// @Failure      400  {object}  dto.ErrorResponse
+// @Failure      401  {object}  dto.ErrorResponse
+// @Failure      403  {object}  dto.ErrorResponse
+// @Failure      404  {object}  dto.ErrorResponse
+// @Failure      500  {object}  dto.ErrorResponse
+// @Router       /api/catalog/{id} [get]
+// @Security     BearerAuth
+func GetCatalogEntry(c echo.Context) error {
+	userID, err := helper.ExtractUserID(c)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusUnauthorized, dto.ErrorResponse{
+			Message: "Failed to extract user information",
+			Details: err.Error(),
+		})
+	}
+
+	def, err := findVisibleDefinition(c, userID)
+	if err != nil {
+		return err
+	}
+
+	var usage dto.CatalogUsage
+	if err := config.DB.Table("exercises").
+		Joins("JOIN workouts ON workouts.id = exercises.workout_id").
+		Where("exercises.definition_id = ? AND workouts.user_id = ?", def.ID, userID).
+		Distinct("exercises.workout_id").
+		Count(&usage.WorkoutCount).Error; err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, dto.ErrorResponse{
+			Message: "Failed to aggregate catalog usage",
+			Details: err.Error(),
+		})
+	}
+
+	var agg struct {
+		LogCount     int64
+		TotalVolume  float64
+		LastLoggedAt *time.Time
+	}
+	if err := config.DB.Table("exercise_logs").
+		Joins(logSetTotals).
+		Select("COUNT(*) AS log_count, COALESCE(SUM("+logTonnageExpr+"), 0) AS total_volume, MAX(exercise_logs.created_at) AS last_logged_at").
+		Where("exercise_logs.definition_id = ? AND exercise_logs.user_id = ?", def.ID, userID).
+		Scan(&agg).Error; err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, dto.ErrorResponse{
+			Message: "Failed to aggregate catalog usage",
+			Details: err.Error(),
+		})
+	}
+	usage.LogCount = agg.LogCount
+	unit, err := userWeightUnit(userID)
+	if err != nil {
+		return err
+	}
+	usage.TotalVolume = helper.FromKg(agg.TotalVolume, unit)
+	usage.WeightUnit = unit
+	usage.LastLoggedAt = agg.LastLoggedAt
+
+	response := toCatalogEntryResponse(def)
+	response.Usage = &usage
+
+	return c.JSON(http.StatusOK, dto.SuccessResponse{
+		Message: "Success Retrieving Catalog Exercise",
+		Data:    response,
+	})
+}
+
+// UpdateCatalogTaxonomy godoc
+// @Summary      Tag a custom catalog exercise
+// @Description  Replaces muscle groups, movement pattern and equipment of a user-owned catalog exercise
+// @Tags         catalog
+// @Accept       json
+// @Produce      json
+// @Param        id        path  int                         true  "Catalog exercise ID"
+// @Param        taxonomy  body  dto.CatalogTaxonomyRequest  true  "Taxonomy payload"
+// @Success      200  {object}  dto.SuccessResponse{data=dto.CatalogEntryResponse}
+// @Failure      400  {object}  dto.ErrorResponse
+// @Failure      401  {object}  dto.ErrorResponse
+// @Failure      403  {object}  dto.ErrorResponse
+// @Failure      404  {object}  dto.ErrorResponse
+// @Failure      500  {object}  dto.ErrorResponse
+// @Router       /api/catalog/{id}/taxonomy [put]
+// @Security     BearerAuth
+func UpdateCatalogTaxonomy(c echo.Context) error {
+	userID, err := helper.ExtractUserID(c)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusUnauthorized, dto.ErrorResponse{
+			Message: "Failed to extract user information",
+			Details: err.Error(),
+		})
+	}
+
+	def, err := findVisibleDefinition(c, userID)
+	if err != nil {
+		return err
+	}
+
+	if def.UserID == nil {
+		return echo.NewHTTPError(http.StatusForbidden, dto.ErrorResponse{
+			Message: "System catalog exercises cannot be modified",
+		})
+	}
+
+	var req dto.CatalogTaxonomyRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, dto.ErrorResponse{
+			Message: "Invalid request body",
+			Details: err.Error(),
+		})
+	}
+
+	primary, secondary, equipment, err := resolveTaxonomy(req)
+	if err != nil {
+		return err
+	}
+
+	if err := config.DB.Transaction(func(tx *gorm.DB) error {
+		return config.ReplaceTaxonomy(tx, &def, req.MovementPattern, primary, secondary, equipment)
+	}); err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, dto.ErrorResponse{
+			Message: "Failed to update catalog exercise taxonomy",
+			Details: err.Error(),
+		})
+	}
+
+	def.MovementPattern = req.MovementPattern
+	def.PrimaryMuscles = primary
+	def.SecondaryMuscles = secondary
+	def.Equipment = equipment
+
+	return c.JSON(http.StatusOK, dto.SuccessResponse{
+		Message: "Catalog exercise taxonomy updated",
+		Data:    toCatalogEntryResponse(def),
+	})
+}
+
+// GetTaxonomy godoc
+// @Summary      List the exercise taxonomy
+// @Description  Retrieves the muscle groups, equipment and movement patterns usable to tag and filter exercises
+// @Tags         catalog
+// @Produce      json
+// @Success      200  {object}  dto.SuccessResponse{data=dto.TaxonomyResponse}
+// @Failure      401  {object}  dto.ErrorResponse
+// @Failure      500  {object}  dto.ErrorResponse
+// @Router       /api/taxonomy [get]
+// @Security     BearerAuth
+func GetTaxonomy(c echo.Context) error {
+	var response dto.TaxonomyResponse
+
+	if err := config.DB.Order("region, name").Find(&response.MuscleGroups).Error; err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, dto.ErrorResponse{
+			Message: "Failed to retrieve muscle groups",
+			Details: err.Error(),
+		})
+	}
+
+	if err := config.DB.Order("name").Find(&response.Equipment).Error; err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, dto.ErrorResponse{
+			Message: "Failed to retrieve equipment",
+			Details: err.Error(),
+		})
+	}
+
+	response.MovementPatterns = model.MovementPatterns
+	response.ExerciseTypes = model.ExerciseTypes
+
+	return c.JSON(http.StatusOK, dto.SuccessResponse{
+		Message: "Success Retrieving Taxonomy",
+		Data:    response,
+	})
+}
@@ -33,8 +33,8 @@ func parseErrorCode(message string, status int) string {
 	}
 }
 
-// Custom global error handler
-func CustomHTTPErrorHandler(err error, c echo.Context) {
+// toErrorResponse converts an error returned by a handler to the body sent to the client
+func toErrorResponse(err error) dto.ErrorResponse {
 	code := http.StatusInternalServerError
 	msg := "Internal Server Error"
 	var details interface{}
@@ -65,17 +65,20 @@ func CustomHTTPErrorHandler(err error, c echo.Context) {
 		msg = err.Error()
 	}
 
-	middleware.MakeLogEntry(c).Error(msg)
-	errCode := parseErrorCode(msg, code)
-
-	res := dto.ErrorResponse{
+	return dto.ErrorResponse{
 		Status:  code,
-		Code:    errCode,
+		Code:    parseErrorCode(msg, code),
 		Message: msg,
 		Details: details,
 	}
+}
+
+// Custom global error handler
+func CustomHTTPErrorHandler(err error, c echo.Context) {
+	res := toErrorResponse(err)
+	middleware.MakeLogEntry(c).Error(res.Message)
 
 	if !c.Response().Committed {
-		c.JSON(code, res)
+		c.JSON(res.Status, res)
 	}
 }
@@ -0,0 +1,302 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"p2gc3/config"
+	"p2gc3/dto"
+	helper "p2gc3/helpers"
+	"p2gc3/model"
+	"sort"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+	"gorm.io/gorm"
+)
+
+// validateGroupShape checks that the number of exercises fits the group type
+func validateGroupShape(groupType string, exerciseCount int) string {
+	switch groupType {
+	case model.GroupTypeSuperset:
+		if exerciseCount != 2 {
+			return "A superset must contain exactly 2 exercises"
+		}
+	case model.GroupTypeCircuit:
+		if exerciseCount < 2 {
+			return "A circuit must contain at least 2 exercises"
+		}
+	case model.GroupTypeGiantSet:
+		if exerciseCount < 3 {
+			return "A giant set must contain at least 3 exercises"
+		}
+	default:
+		return "Group type must be one of superset, circuit, giant_set"
+	}
+	return ""
+}
+
+// buildGroupResponses maps the groups of a workout into response DTOs, exercises sorted by their order
+func buildGroupResponses(groups []model.ExerciseGroup, exercises []model.Exercise) []dto.ExerciseGroupResponse {
+	response := []dto.ExerciseGroupResponse{}
+	for _, g := range groups {
+		items := []dto.ExerciseGroupItem{}
+		for _, ex := range exercises {
+			if ex.GroupID != nil && *ex.GroupID == g.ID {
+				items = append(items, dto.ExerciseGroupItem{
+					ExerciseID: ex.ID,
+					Name:       ex.Name,
+					Order:      ex.GroupOrder,
+				})
+			}
+		}
+		sort.Slice(items, func(i, j int) bool { return items[i].Order < items[j].Order })
+
+		response = append(response, dto.ExerciseGroupResponse{
+			ID:          g.ID,
+			Name:        g.Name,
+			Type:        g.Type,
+			Rounds:      g.Rounds,
+			RestSeconds: g.RestSeconds,
+			Exercises:   items,
+		})
+	}
+	return response
+}
+
+// CreateExerciseGroup godoc
+// @Summary      Group exercises of a workout
+// @Description  Creates a superset, circuit or giant set out of exercises belonging to the workout
+// @Tags         workouts
+// @Accept       json
+// @Produce      json
+// @Param        id     path  int                             true  "Workout ID"
+// @Param        group  body  dto.ExerciseGroupCreateRequest  true  "Group payload"
+// @Success      201  {object}  dto.SuccessResponse{data=dto.ExerciseGroupResponse}
+// @Failure      400  {object}  dto.ErrorResponse
+// @Failure      401  {object}  dto.ErrorResponse
+// @Failure      403  {object}  dto.ErrorResponse
+// @Failure      404  {object}  dto.ErrorResponse
+// @Failure      500  {object}  dto.ErrorResponse
+// @Router       /api/workouts/{id}/groups [post]
+// @Security     BearerAuth
+func CreateExerciseGroup(c echo.Context) error {
+	userID, err := helper.ExtractUserID(c)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusUnauthorized, dto.ErrorResponse{
+			Message: "Failed to extract user information",
+			Details: err.Error(),
+		})
+	}
+
+	workoutID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, dto.ErrorResponse{
+			Message: "Invalid workout ID",
+			Details: err.Error(),
+		})
+	}
+
+	var req dto.ExerciseGroupCreateRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, dto.ErrorResponse{
+			Message: "Invalid input",
+			Details: err.Error(),
+		})
+	}
+
+	if msg := validateGroupShape(req.Type, len(req.ExerciseIDs)); msg != "" {
+		return echo.NewHTTPError(http.StatusBadRequest, dto.ErrorResponse{
+			Message: "Invalid input: " + msg,
+		})
+	}
+
+	if req.Rounds <= 0 || req.RestSeconds < 0 {
+		return echo.NewHTTPError(http.StatusBadRequest, dto.ErrorResponse{
+			Message: "Invalid input: rounds must be positive and rest_seconds cannot be negative",
+		})
+	}
+
+	var workout model.Workout
+	err = config.DB.Preload("Exercises").First(&workout, workoutID).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return echo.NewHTTPError(http.StatusNotFound, dto.ErrorResponse{
+			Message: "Workout not found",
+		})
+	} else if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, dto.ErrorResponse{
+			Message: "Failed to fetch workout",
+			Details: err.Error(),
+		})
+	}
+
+	if workout.UserID != userID {
+		return echo.NewHTTPError(http.StatusForbidden, dto.ErrorResponse{
+			Message: "You are not authorized to group exercises of this workout",
+		})
+	}
+
+	// Every exercise must belong to this workout and not already be grouped
+	byID := map[uint]model.Exercise{}
+	for _, ex := range workout.Exercises {
+		byID[ex.ID] = ex
+	}
+	seen := map[uint]bool{}
+	for _, id := range req.ExerciseIDs {
+		ex, ok := byID[id]
+		if !ok {
+			return echo.NewHTTPError(http.StatusBadRequest, dto.ErrorResponse{
+				Message: "Invalid input: exercise does not belong to this workout",
+				Details: id,
+			})
+		}
+		if ex.GroupID != nil {
+			return echo.NewHTTPError(http.StatusConflict, dto.ErrorResponse{
+				Message: "Exercise is already part of another group",
+				Details: id,
+			})
+		}
+		if seen[id] {
+			return echo.NewHTTPError(http.StatusBadRequest, dto.ErrorResponse{
+				Message: "Invalid input: duplicated exercise in group",
+				Details: id,
+			})
+		}
+		seen[id] = true
+	}
+
+	group := model.ExerciseGroup{
+		WorkoutID:   workout.ID,
+		Name:        req.Name,
+		Type:        req.Type,
+		Rounds:      req.Rounds,
+		RestSeconds: req.RestSeconds,
+	}
+
+	var grouped []model.Exercise
+	err = config.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&group).Error; err != nil {
+			return echo.NewHTTPError(http.StatusInternalServerError, dto.ErrorResponse{
+				Message: "Failed to create exercise group",
+				Details: err.Error(),
+			})
+		}
+
+		for i, id := range req.ExerciseIDs {
+			ex := byID[id]
+			ex.GroupID = &group.ID
+			ex.GroupOrder = i + 1
+			if err := tx.Model(&model.Exercise{}).Where("id = ?", id).
+				Updates(map[string]interface{}{"group_id": group.ID, "group_order": i + 1}).Error; err != nil {
+				return echo.NewHTTPError(http.StatusInternalServerError, dto.ErrorResponse{
+					Message: "Failed to assign exercise to group",
+					Details: err.Error(),
+				})
+			}
+			grouped = append(grouped, ex)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusCreated, dto.SuccessResponse{
+		Message: "Exercise group successfully created",
+		Data:    buildGroupResponses([]model.ExerciseGroup{group}, grouped)[0],
+	})
+}
+
+// DeleteExerciseGroup godoc
+// @Summary      Ungroup exercises
+// @Description  Deletes an exercise group; its exercises stay in the workout as standalone exercises
+// @Tags         workouts
+// @Produce      json
+// @Param        id       path  int  true  "Workout ID"
+// @Param        groupId  path  int  true  "Group ID"
+// @Success      200  {object}  dto.SuccessResponse
+// @Failure      400  {object}  dto.ErrorResponse
+// @Failure      401  {object}  dto.ErrorResponse
+// @Failure      403  {object}  dto.ErrorResponse
+// @Failure      404  {object}  dto.ErrorResponse
+// @Failure      500  {object}  dto.ErrorResponse
+// @Router       /api/workouts/{id}/groups/{groupId} [delete]
+// @Security     BearerAuth
+func DeleteExerciseGroup(c echo.Context) error {
+	userID, err := helper.ExtractUserID(c)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusUnauthorized, dto.ErrorResponse{
+			Message: "Unauthorized",
+			Details: err.Error(),
+		})
+	}
+
+	workoutID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, dto.ErrorResponse{
+			Message: "Invalid workout ID",
+			Details: err.Error(),
+		})
+	}
+
+	groupID, err := strconv.Atoi(c.Param("groupId"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, dto.ErrorResponse{
+			Message: "Invalid group ID",
+			Details: err.Error(),
+		})
+	}
+
+	var group model.ExerciseGroup
+	err = config.DB.Preload("Workout").
+		Where("workout_id = ?", workoutID).
+		First(&group, groupID).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return echo.NewHTTPError(http.StatusNotFound, dto.ErrorResponse{
+			Message: "Exercise group not found",
+		})
+	} else if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, dto.ErrorResponse{
+			Message: "Failed to retrieve exercise group",
+			Details: err.Error(),
+		})
+	}
+
+	if group.Workout.UserID != userID {
+		return echo.NewHTTPError(http.StatusForbidden, dto.ErrorResponse{
+			Message: "You are not authorized to delete this exercise group",
+		})
+	}
+
+	err = config.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&model.Exercise{}).Where("group_id = ?", group.ID).
+			Updates(map[string]interface{}{"group_id": nil, "group_order": 0}).Error; err != nil {
+			return echo.NewHTTPError(http.StatusInternalServerError, dto.ErrorResponse{
+				Message: "Failed to ungroup exercises",
+				Details: err.Error(),
+			})
+		}
+
+		if err := tx.Delete(&group).Error; err != nil {
+			return echo.NewHTTPError(http.StatusInternalServerError, dto.ErrorResponse{
+				Message: "Failed to delete exercise group",
+				Details: err.Error(),
+			})
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, dto.SuccessResponse{
+		Message: "Exercise group deleted successfully",
+		Data: dto.ExerciseGroupResponse{
+			ID:          group.ID,
+			Name:        group.Name,
+			Type:        group.Type,
+			Rounds:      group.Rounds,
+			RestSeconds: group.RestSeconds,
+			Exercises:   []dto.ExerciseGroupItem{},
+		},
+	})
+}
@@ -8,11 +8,55 @@ import (
 	helper "p2gc3/helpers"
 	"p2gc3/model"
 	"strconv"
+	"strings"
 
 	"github.com/labstack/echo/v4"
 	"gorm.io/gorm"
 )
 
+func toExerciseResponse(exercise model.Exercise) dto.ExerciseResponse {
+	return dto.ExerciseResponse{
+		ID:           exercise.ID,
+		WorkoutID:    exercise.WorkoutID,
+		DefinitionID: exercise.DefinitionID,
+		Name:         exercise.Name,
+		Description:  exercise.Description,
+	}
+}
+
+// findOwnedExercise loads an exercise by the :id path param and checks its workout belongs to the user
+func findOwnedExercise(c echo.Context, userID uint) (model.Exercise, error) {
+	var exercise model.Exercise
+
+	exerciseID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return exercise, echo.NewHTTPError(http.StatusBadRequest, dto.ErrorResponse{
+			Message: "Invalid exercise ID",
+			Details: err.Error(),
+		})
+	}
+
+	err = config.DB.Preload("Workout").First(&exercise, exerciseID).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return exercise, echo.NewHTTPError(http.StatusNotFound, dto.ErrorResponse{
+			Message: "Exercise not found",
+		})
+	} else if err != nil {
+		return exercise, echo.NewHTTPError(http.StatusInternalServerError, dto.ErrorResponse{
+			Message: "Failed to retrieve exercise",
+			Details: err.Error(),
+		})
+	}
+
+	if exercise.Workout.UserID != userID {
+		return exercise, echo.NewHTTPError(http.StatusForbidden, dto.ErrorResponse{
+			Message: "You are not authorized to access this exercise",
+		})
+	}
+
+	return exercise, nil
+}
+
 // CreateExercise godoc
 // @Summary      Create a new exercise
 // @Description  Adds a new exercise to the database
@@ -26,7 +70,7 @@ import (
 // @Failure      403  {object}  dto.ErrorResponse
 // @Failure      404  {object}  dto.ErrorResponse
 // @Failure      500  {object}  dto.ErrorResponse
-// @Router       /exercises [post]
+// @Router       /api/exercises [post]
 // @Security     BearerAuth
 func CreateExercise(c echo.Context) error {
 	var req dto.ExerciseCreateRequest
@@ -46,12 +90,6 @@ func CreateExercise(c echo.Context) error {
 		})
 	}
 
-	if req.Description == "" || req.Name == "" {
-		return echo.NewHTTPError(http.StatusBadRequest, dto.ErrorResponse{
-			Message: "Exercise name and description are required",
-		})
-	}
-
 	var workout model.Workout
 
 	if err := config.DB.First(&workout, req.WorkoutID).Error; err != nil {
@@ -72,10 +110,9 @@ func CreateExercise(c echo.Context) error {
 		})
 	}
 
-	exercise := model.Exercise{
-		WorkoutID:   req.WorkoutID,
-		Name:        req.Name,
-		Description: req.Description,
+	exercise, err := newExercise(userID, req)
+	if err != nil {
+		return err
 	}
 
 	if err := config.DB.Create(&exercise).Error; err != nil {
@@ -87,14 +124,107 @@ func CreateExercise(c echo.Context) error {
 
 	return c.JSON(http.StatusCreated, dto.SuccessResponse{
 		Message: "Exercise successfully created",
-		Data: dto.ExerciseResponse{
-			Name:        exercise.Name,
-			Description: exercise.Description,
-		},
+		Data:    toExerciseResponse(exercise),
 	})
 
 }
 
+// usableDefinition fetches a catalog entry the user may link exercises to: a system entry or
+// one of their custom entries
+func usableDefinition(db *gorm.DB, userID, definitionID uint) (model.ExerciseDefinition, error) {
+	var definition model.ExerciseDefinition
+	err := db.First(&definition, definitionID).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return definition, echo.NewHTTPError(http.StatusNotFound, dto.ErrorResponse{
+			Message: "Catalog exercise not found",
+		})
+	} else if err != nil {
+		return definition, echo.NewHTTPError(http.StatusInternalServerError, dto.ErrorResponse{
+			Message: "Failed to fetch catalog exercise",
+			Details: err.Error(),
+		})
+	}
+
+	if definition.UserID != nil && *definition.UserID != userID {
+		return definition, echo.NewHTTPError(http.StatusForbidden, dto.ErrorResponse{
+			Message: "You are not authorized to use this catalog exercise",
+		})
+	}
+	return definition, nil
+}
+
+// relinkExercise points an edited exercise at its catalog entry: definitionID when given,
+// otherwise the entry matching the new name when the exercise was renamed. A renamed exercise
+// is another movement, keeping the old entry would mix its logs and records with the old one.
+func relinkExercise(db *gorm.DB, userID uint, exercise *model.Exercise, name, description string, definitionID uint) error {
+	if definitionID != 0 {
+		definition, err := usableDefinition(db, userID, definitionID)
+		if err != nil {
+			return err
+		}
+		exercise.DefinitionID = &definition.ID
+		return nil
+	}
+
+	if strings.EqualFold(strings.TrimSpace(name), strings.TrimSpace(exercise.Name)) {
+		return nil
+	}
+	definition, err := config.FindOrCreateDefinition(db, userID, name, description, model.TypeStrength)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, dto.ErrorResponse{
+			Message: "Failed to resolve catalog exercise",
+			Details: err.Error(),
+		})
+	}
+	exercise.DefinitionID = &definition.ID
+	return nil
+}
+
+// newExercise builds an exercise for req.WorkoutID linked to the catalog, either the requested
+// catalog entry or the user's entry with the same name. The workout must already be checked.
+func newExercise(userID uint, req dto.ExerciseCreateRequest) (model.Exercise, error) {
+	// A catalog entry can be referenced directly, its name and description act as defaults
+	var definition model.ExerciseDefinition
+	if req.DefinitionID != 0 {
+		var err error
+		definition, err = usableDefinition(config.DB, userID, req.DefinitionID)
+		if err != nil {
+			return model.Exercise{}, err
+		}
+
+		if req.Name == "" {
+			req.Name = definition.Name
+		}
+		if req.Description == "" {
+			req.Description = definition.Description
+		}
+	}
+
+	if req.Description == "" || req.Name == "" {
+		return model.Exercise{}, echo.NewHTTPError(http.StatusBadRequest, dto.ErrorResponse{
+			Message: "Exercise name and description are required",
+		})
+	}
+
+	if definition.ID == 0 {
+		var err error
+		definition, err = config.FindOrCreateDefinition(config.DB, userID, req.Name, req.Description, model.TypeStrength)
+		if err != nil {
+			return model.Exercise{}, echo.NewHTTPError(http.StatusInternalServerError, dto.ErrorResponse{
+				Message: "Failed to resolve catalog exercise",
+				Details: err.Error(),
+			})
+		}
+	}
+
+	return model.Exercise{
+		WorkoutID:    req.WorkoutID,
+		DefinitionID: &definition.ID,
+		Name:         req.Name,
+		Description:  req.Description,
+	}, nil
+}
+
 // DeleteExercise godoc
 // @Summary      Delete an exercise
 // @Description  Deletes an exercise by ID
@@ -107,7 +237,7 @@ func CreateExercise(c echo.Context) error {
 // @Failure      403  {object}  dto.ErrorResponse
 // @Failure      404  {object}  dto.ErrorResponse
 // @Failure      500  {object}  dto.ErrorResponse
-// @Router       /exercises/{id} [delete]
+// @Router       /api/exercises/{id} [delete]
 // @Security     BearerAuth
 func DeleteExercise(c echo.Context) error {
 	userID, err := helper.ExtractUserID(c)
@@ -146,27 +276,368 @@ func DeleteExercise(c echo.Context) error {
 		})
 	}
 
-	// Delete associated logs
-	if err := config.DB.Where("exercise_id = ?", exercise.ID).Delete(&model.ExerciseLog{}).Error; err != nil {
+	// Delete associated media, files are removed once the exercise is gone
+	var media []model.ExerciseMedia
+	err = config.DB.Transaction(func(tx *gorm.DB) error {
+		media, err = deleteExerciseCascade(tx, exercise)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	removeMediaBlobs(c.Request().Context(), media)
+
+	return c.JSON(http.StatusOK, dto.SuccessResponse{
+		Message: "Exercise deleted successfully",
+		Data:    toExerciseResponse(exercise),
+	})
+}
+
+// GetWorkoutExercises godoc
+// @Summary      List exercises of a workout
+// @Description  Retrieves all exercises of a workout owned by the authenticated user
+// @Tags         exercises
+// @Produce      json
+// @Param        id  path  int  true  "Workout ID"
+// @Success      200  {object}  dto.SuccessResponse{data=[]dto.ExerciseResponse}
+// @Failure      400  {object}  dto.ErrorResponse
+// @Failure      401  {object}  dto.ErrorResponse
+// @Failure      403  {object}  dto.ErrorResponse
+// @Failure      404  {object}  dto.ErrorResponse
+// @Failure      500  {object}  dto.ErrorResponse
+// @Router       /api/workouts/{id}/exercises [get]
+// @Security     BearerAuth
+func GetWorkoutExercises(c echo.Context) error {
+	userID, err := helper.ExtractUserID(c)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusUnauthorized, dto.ErrorResponse{
+			Message: "Failed to extract user information",
+			Details: err.Error(),
+		})
+	}
+
+	workoutID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, dto.ErrorResponse{
+			Message: "Invalid workout ID",
+			Details: err.Error(),
+		})
+	}
+
+	var workout model.Workout
+	err = config.DB.First(&workout, workoutID).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return echo.NewHTTPError(http.StatusNotFound, dto.ErrorResponse{
+			Message: "Workout not found",
+		})
+	} else if err != nil {
 		return echo.NewHTTPError(http.StatusInternalServerError, dto.ErrorResponse{
-			Message: "Failed to delete associated exercise logs",
+			Message: "Failed to fetch workout",
 			Details: err.Error(),
 		})
 	}
 
-	// Delete exercise
-	if err := config.DB.Delete(&exercise).Error; err != nil {
+	if workout.UserID != userID {
+		return echo.NewHTTPError(http.StatusForbidden, dto.ErrorResponse{
+			Message: "You are not authorized to view this workout",
+		})
+	}
+
+	var exercises []model.Exercise
+	if err := config.DB.Where("workout_id = ?", workout.ID).Order("id").Find(&exercises).Error; err != nil {
 		return echo.NewHTTPError(http.StatusInternalServerError, dto.ErrorResponse{
-			Message: "Failed to delete exercise",
+			Message: "Failed to retrieve exercises",
 			Details: err.Error(),
 		})
 	}
 
+	response := []dto.ExerciseResponse{}
+	for _, ex := range exercises {
+		response = append(response, toExerciseResponse(ex))
+	}
+
 	return c.JSON(http.StatusOK, dto.SuccessResponse{
-		Message: "Exercise deleted successfully",
-		Data: dto.ExerciseResponse{
-			Name:        exercise.Name,
-			Description: exercise.Description,
-		},
+		Message: "Success Retrieving Exercises",
+		Data:    response,
+	})
+}
+
+// GetExerciseByID godoc
+// @Summary      Get exercise by ID
+// @Description  Retrieves a single exercise owned through its workout
+// @Tags         exercises
+// @Produce      json
+// @Param        id  path  int  true  "Exercise ID"
+// @Success      200  {object}  dto.SuccessResponse{data=dto.ExerciseResponse}
+// @Failure      400  {object}  dto.ErrorResponse
+// @Failure      401  {object}  dto.ErrorResponse
+// @Failure      403  {object}  dto.ErrorResponse
+// @Failure      404  {object}  dto.ErrorResponse
+// @Failure      500  {object}  dto.ErrorResponse
+// @Router       /api/exercises/{id} [get]
+// @Security     BearerAuth
+func GetExerciseByID(c echo.Context) error {
+	userID, err := helper.ExtractUserID(c)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusUnauthorized, dto.ErrorResponse{
+			Message: "Failed to extract user information",
+			Details: err.Error(),
+		})
+	}
+
+	exercise, err := findOwnedExercise(c, userID)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, dto.SuccessResponse{
+		Message: "Success Retrieving Exercise",
+		Data:    toExerciseResponse(exercise),
+	})
+}
+
+// UpdateExercise godoc
+// @Summary      Replace an exercise
+// @Description  Updates name and description of an exercise. A rename links the exercise to the catalog entry matching the new name unless definition_id is sent
+// @Tags         exercises
+// @Accept       json
+// @Produce      json
+// @Param        id        path  int                        true  "Exercise ID"
+// @Param        exercise  body  dto.ExerciseUpdateRequest  true  "Updated exercise data"
+// @Success      200  {object}  dto.SuccessResponse{data=dto.ExerciseResponse}
+// @Failure      400  {object}  dto.ErrorResponse
+// @Failure      401  {object}  dto.ErrorResponse
+// @Failure      403  {object}  dto.ErrorResponse
+// @Failure      404  {object}  dto.ErrorResponse
+// @Failure      500  {object}  dto.ErrorResponse
+// @Router       /api/exercises/{id} [put]
+// @Security     BearerAuth
+func UpdateExercise(c echo.Context) error {
+	userID, err := helper.ExtractUserID(c)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusUnauthorized, dto.ErrorResponse{
+			Message: "Failed to extract user information",
+			Details: err.Error(),
+		})
+	}
+
+	exercise, err := findOwnedExercise(c, userID)
+	if err != nil {
+		return err
+	}
+
+	var req dto.ExerciseUpdateRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, dto.ErrorResponse{
+			Message: "Invalid request body",
+			Details: err.Error(),
+		})
+	}
+
+	if req.Name == "" || req.Description == "" {
+		return echo.NewHTTPError(http.StatusBadRequest, dto.ErrorResponse{
+			Message: "Exercise name and description are required",
+		})
+	}
+
+	if err := relinkExercise(config.DB, userID, &exercise, req.Name, req.Description, req.DefinitionID); err != nil {
+		return err
+	}
+	exercise.Name = req.Name
+	exercise.Description = req.Description
+
+	if err := config.DB.Model(&exercise).Updates(map[string]interface{}{
+		"name":          exercise.Name,
+		"description":   exercise.Description,
+		"definition_id": exercise.DefinitionID,
+	}).Error; err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, dto.ErrorResponse{
+			Message: "Failed to update exercise",
+			Details: err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, dto.SuccessResponse{
+		Message: "Exercise updated successfully",
+		Data:    toExerciseResponse(exercise),
 	})
 }
+
+// PatchExercise godoc
+// @Summary      Partially update an exercise
+// @Description  Updates only the provided fields of an exercise. A rename links the exercise to the catalog entry matching the new name unless definition_id is sent
+// @Tags         exercises
+// @Accept       json
+// @Produce      json
+// @Param        id        path  int                       true  "Exercise ID"
+// @Param        exercise  body  dto.ExercisePatchRequest  true  "Fields to update"
+// @Success      200  {object}  dto.SuccessResponse{data=dto.ExerciseResponse}
+// @Failure      400  {object}  dto.ErrorResponse
+// @Failure      401  {object}  dto.ErrorResponse
+// @Failure      403  {object}  dto.ErrorResponse
+// @Failure      404  {object}  dto.ErrorResponse
+// @Failure      500  {object}  dto.ErrorResponse
+// @Router       /api/exercises/{id} [patch]
+// @Security     BearerAuth
+func PatchExercise(c echo.Context) error {
+	userID, err := helper.ExtractUserID(c)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusUnauthorized, dto.ErrorResponse{
+			Message: "Failed to extract user information",
+			Details: err.Error(),
+		})
+	}
+
+	exercise, err := findOwnedExercise(c, userID)
+	if err != nil {
+		return err
+	}
+
+	var req dto.ExercisePatchRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, dto.ErrorResponse{
+			Message: "Invalid request body",
+			Details: err.Error(),
+		})
+	}
+
+	updates := map[string]interface{}{}
+	if req.Description != nil {
+		if *req.Description == "" {
+			return echo.NewHTTPError(http.StatusBadRequest, dto.ErrorResponse{
+				Message: "Invalid input: exercise description cannot be empty",
+			})
+		}
+		updates["description"] = *req.Description
+		exercise.Description = *req.Description
+	}
+	if req.Name != nil || req.DefinitionID != nil {
+		name := exercise.Name
+		if req.Name != nil {
+			if *req.Name == "" {
+				return echo.NewHTTPError(http.StatusBadRequest, dto.ErrorResponse{
+					Message: "Invalid input: exercise name cannot be empty",
+				})
+			}
+			name = *req.Name
+		}
+		var definitionID uint
+		if req.DefinitionID != nil {
+			definitionID = *req.DefinitionID
+		}
+
+		if err := relinkExercise(config.DB, userID, &exercise, name, exercise.Description, definitionID); err != nil {
+			return err
+		}
+		updates["name"] = name
+		updates["definition_id"] = exercise.DefinitionID
+		exercise.Name = name
+	}
+
+	if len(updates) == 0 {
+		return echo.NewHTTPError(http.StatusBadRequest, dto.ErrorResponse{
+			Message: "Invalid input: no fields to update",
+		})
+	}
+
+	if err := config.DB.Model(&exercise).Updates(updates).Error; err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, dto.ErrorResponse{
+			Message: "Failed to update exercise",
+			Details: err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, dto.SuccessResponse{
+		Message: "Exercise updated successfully",
+		Data:    toExerciseResponse(exercise),
+	})
+}
+
+// deleteExerciseCascade deletes an exercise with its media, swap history and logs, and
+// dissolves its group when the group becomes too small. It returns the deleted media, their
+// files are left to the caller.
+func deleteExerciseCascade(db *gorm.DB, exercise model.Exercise) ([]model.ExerciseMedia, error) {
+	var media []model.ExerciseMedia
+	if err := db.Where("exercise_id = ?", exercise.ID).Find(&media).Error; err != nil {
+		return nil, echo.NewHTTPError(http.StatusInternalServerError, dto.ErrorResponse{
+			Message: "Failed to retrieve associated media",
+			Details: err.Error(),
+		})
+	}
+	if err := db.Where("exercise_id = ?", exercise.ID).Delete(&model.ExerciseMedia{}).Error; err != nil {
+		return nil, echo.NewHTTPError(http.StatusInternalServerError, dto.ErrorResponse{
+			Message: "Failed to delete associated media",
+			Details: err.Error(),
+		})
+	}
+
+	if err := db.Where("exercise_id = ?", exercise.ID).Delete(&model.ExerciseSwap{}).Error; err != nil {
+		return nil, echo.NewHTTPError(http.StatusInternalServerError, dto.ErrorResponse{
+			Message: "Failed to delete associated swap history",
+			Details: err.Error(),
+		})
+	}
+
+	// Delete associated logs
+	if err := config.DeleteLogs(db, "exercise_id = ?", exercise.ID); err != nil {
+		return nil, echo.NewHTTPError(http.StatusInternalServerError, dto.ErrorResponse{
+			Message: "Failed to delete associated exercise logs",
+			Details: err.Error(),
+		})
+	}
+
+	// Delete exercise
+	if err := db.Delete(&exercise).Error; err != nil {
+		return nil, echo.NewHTTPError(http.StatusInternalServerError, dto.ErrorResponse{
+			Message: "Failed to delete exercise",
+			Details: err.Error(),
+		})
+	}
+
+	if exercise.GroupID != nil {
+		if err := dissolveUndersizedGroup(db, *exercise.GroupID); err != nil {
+			return nil, err
+		}
+	}
+
+	return media, nil
+}
+
+// dissolveUndersizedGroup ungroups the remaining exercises of a group that lost a member and
+// deletes it when they no longer fit its type, e.g. the last exercise of a superset
+func dissolveUndersizedGroup(db *gorm.DB, groupID uint) error {
+	var group model.ExerciseGroup
+	if err := db.First(&group, groupID).Error; err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, dto.ErrorResponse{
+			Message: "Failed to retrieve exercise group",
+			Details: err.Error(),
+		})
+	}
+
+	var remaining int64
+	if err := db.Model(&model.Exercise{}).Where("group_id = ?", group.ID).Count(&remaining).Error; err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, dto.ErrorResponse{
+			Message: "Failed to count grouped exercises",
+			Details: err.Error(),
+		})
+	}
+	if validateGroupShape(group.Type, int(remaining)) == "" {
+		return nil
+	}
+
+	if err := db.Model(&model.Exercise{}).Where("group_id = ?", group.ID).
+		Updates(map[string]interface{}{"group_id": nil, "group_order": 0}).Error; err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, dto.ErrorResponse{
+			Message: "Failed to ungroup exercises",
+			Details: err.Error(),
+		})
+	}
+	if err := db.Delete(&group).Error; err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, dto.ErrorResponse{
+			Message: "Failed to delete exercise group",
+			Details: err.Error(),
+		})
+	}
+	return nil
+}
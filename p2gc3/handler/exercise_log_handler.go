@@ -1,30 +1,587 @@
 package handler
 
 import (
+	"encoding/json"
+	"errors"
 	"net/http"
 	"p2gc3/config"
 	"p2gc3/dto"
 	helper "p2gc3/helpers"
 	"p2gc3/model"
+	"reflect"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/labstack/echo/v4"
+	"gorm.io/gorm"
 )
 
+const (
+	logsDefaultLimit = 20
+	logsMaxLimit     = 100
+)
+
+// logSortColumns are the columns GET /api/logs can be sorted by
+var logSortColumns = map[string]string{
+	"created_at": "exercise_logs.created_at",
+	"weight":     "exercise_logs.weight",
+}
+
+// toExerciseLogDetail converts a log for the response, with weights in unit
+func toExerciseLogDetail(log model.ExerciseLog, unit string) dto.ExerciseLogDetailResponse {
+	return dto.ExerciseLogDetailResponse{
+		ID:               log.ID,
+		ExerciseID:       log.ExerciseID,
+		ExerciseName:     log.Exercise.Name,
+		WorkoutID:        log.Exercise.WorkoutID,
+		DefinitionID:     log.DefinitionID,
+		SessionID:        log.SessionID,
+		SetCount:         log.SetCount,
+		RepCount:         log.RepCount,
+		Weight:           helper.FromKg(log.Weight, unit),
+		WeightUnit:       unit,
+		DurationSeconds:  log.DurationSeconds,
+		DistanceMeters:   log.DistanceMeters,
+		PaceSecondsPerKm: log.PaceSecondsPerKm,
+		AddedWeight:      helper.FromKg(log.AddedWeight, unit),
+		AssistedWeight:   helper.FromKg(log.AssistedWeight, unit),
+		Notes:            log.Notes,
+		Tempo:            log.Tempo,
+		RestSeconds:      log.RestSeconds,
+		PerceivedEffort:  log.PerceivedEffort,
+		Round:            log.Round,
+		CreatedAt:        log.CreatedAt,
+		Sets:             toLogSetResponses(log.Sets, unit),
+	}
+}
+
+func toLogSetResponses(sets []model.LogSet, unit string) []dto.LogSetResponse {
+	response := []dto.LogSetResponse{}
+	for _, s := range sets {
+		response = append(response, dto.LogSetResponse{
+			Position: s.Position,
+			SetType:  s.SetType,
+			RepCount: s.RepCount,
+			Weight:   helper.FromKg(s.Weight, unit),
+			RPE:      s.RPE,
+			RIR:      s.RIR,
+		})
+	}
+	return response
+}
+
+// toLogSets numbers the requested sets in the order they were sent, converting weights from unit to kg
+func toLogSets(req []dto.LogSetRequest, unit string) []model.LogSet {
+	sets := make([]model.LogSet, 0, len(req))
+	for i, s := range req {
+		setType := s.SetType
+		if setType == "" {
+			setType = model.SetTypeWorking
+		}
+		sets = append(sets, model.LogSet{
+			Position: i + 1,
+			SetType:  setType,
+			RepCount: s.RepCount,
+			Weight:   helper.ToKg(s.Weight, unit),
+			RPE:      s.RPE,
+			RIR:      s.RIR,
+		})
+	}
+	return sets
+}
+
+// withSets preloads the sets of logs in the order they were performed
+func withSets(db *gorm.DB) *gorm.DB {
+	return db.Preload("Sets", func(db *gorm.DB) *gorm.DB { return db.Order("position") })
+}
+
+func logValues(log model.ExerciseLog) helper.LogValues {
+	return helper.LogValues{
+		SetCount:        log.SetCount,
+		RepCount:        log.RepCount,
+		Weight:          log.Weight,
+		DurationSeconds: log.DurationSeconds,
+		DistanceMeters:  log.DistanceMeters,
+		AddedWeight:     log.AddedWeight,
+		AssistedWeight:  log.AssistedWeight,
+	}
+}
+
+// errSetsDerivedFields is returned when a request sends both sets and the fields derived from them
+var errSetsDerivedFields = echo.NewHTTPError(http.StatusBadRequest, dto.ErrorResponse{
+	Message: "Invalid input: set_count, rep_count, weight and added_weight are derived from sets",
+})
+
+// validateLogMetadata checks the notes, tempo, rest and effort of a log
+func validateLogMetadata(log model.ExerciseLog) error {
+	if err := helper.ValidateLogMetadata(helper.LogMetadata{
+		Notes:           log.Notes,
+		Tempo:           log.Tempo,
+		RestSeconds:     log.RestSeconds,
+		PerceivedEffort: log.PerceivedEffort,
+	}); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, dto.ErrorResponse{
+			Message: "Invalid input",
+			Details: err.Error(),
+		})
+	}
+	return nil
+}
+
+// validateLogTime rejects log timestamps in the future or before 1970
+func validateLogTime(t time.Time) error {
+	if err := helper.ValidateLogTime(t, time.Now()); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, dto.ErrorResponse{
+			Message: "Invalid created_at",
+			Details: err.Error(),
+		})
+	}
+	return nil
+}
+
+// applyLogSets validates the sets of a log and derives its aggregate fields from them.
+// Aggregate-only logs are left untouched.
+func applyLogSets(exerciseType string, log *model.ExerciseLog) error {
+	if len(log.Sets) == 0 {
+		return nil
+	}
+
+	if exerciseType != model.TypeStrength && exerciseType != model.TypeBodyweight {
+		return echo.NewHTTPError(http.StatusBadRequest, dto.ErrorResponse{
+			Message: "Invalid input: sets are only supported for strength and bodyweight exercises",
+		})
+	}
+
+	if err := deriveFromSets(exerciseType, log); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, dto.ErrorResponse{
+			Message: "Invalid sets",
+			Details: err.Error(),
+		})
+	}
+	return nil
+}
+
+// deriveFromSets validates the sets of a strength or bodyweight log and sets its aggregate fields
+func deriveFromSets(exerciseType string, log *model.ExerciseLog) error {
+	values := make([]helper.SetValues, 0, len(log.Sets))
+	for _, s := range log.Sets {
+		values = append(values, helper.SetValues{
+			SetType:  s.SetType,
+			RepCount: s.RepCount,
+			Weight:   s.Weight,
+			RPE:      s.RPE,
+			RIR:      s.RIR,
+		})
+	}
+
+	if err := helper.ValidateSets(values); err != nil {
+		return err
+	}
+
+	setCount, repCount, weight := helper.AggregateSets(values)
+	log.SetCount = setCount
+	log.RepCount = repCount
+	// Set weights of bodyweight exercises are the load added on top of the body
+	if exerciseType == model.TypeBodyweight {
+		log.Weight = 0
+		log.AddedWeight = weight
+	} else {
+		log.Weight = weight
+	}
+	return nil
+}
+
+// exerciseTypeOf returns the exercise type of the catalog entry an exercise references
+func exerciseTypeOf(exercise model.Exercise) (string, error) {
+	return definitionType(exercise.DefinitionID)
+}
+
+// definitionType returns the exercise type of a catalog entry, strength for exercises and logs
+// not linked to the catalog
+func definitionType(definitionID *uint) (string, error) {
+	if definitionID == nil {
+		return model.TypeStrength, nil
+	}
+
+	var definition model.ExerciseDefinition
+	if err := config.DB.First(&definition, *definitionID).Error; err != nil {
+		return "", echo.NewHTTPError(http.StatusInternalServerError, dto.ErrorResponse{
+			Message: "Failed to fetch catalog exercise",
+			Details: err.Error(),
+		})
+	}
+	return definition.ExerciseType, nil
+}
+
+// validateLogRound checks a log round against the group of the exercise, 0 means no round
+func validateLogRound(exercise model.Exercise, round int) error {
+	if round == 0 {
+		return nil
+	}
+
+	// Rounds only make sense for exercises performed inside a group
+	if exercise.GroupID == nil {
+		return echo.NewHTTPError(http.StatusBadRequest, dto.ErrorResponse{
+			Message: "Invalid input: round can only be set for grouped exercises",
+		})
+	}
+
+	var group model.ExerciseGroup
+	if err := config.DB.First(&group, *exercise.GroupID).Error; err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, dto.ErrorResponse{
+			Message: "Failed to fetch exercise group",
+			Details: err.Error(),
+		})
+	}
+
+	if round < 1 || round > group.Rounds {
+		return echo.NewHTTPError(http.StatusBadRequest, dto.ErrorResponse{
+			Message: "Invalid input: round is out of range for this group",
+			Details: map[string]int{"rounds": group.Rounds},
+		})
+	}
+	return nil
+}
+
+// findOwnedLog loads a log by the :id path param, with its exercise, and checks it belongs to the user
+func findOwnedLog(c echo.Context, userID uint) (model.ExerciseLog, error) {
+	var log model.ExerciseLog
+
+	logID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return log, echo.NewHTTPError(http.StatusBadRequest, dto.ErrorResponse{
+			Message: "Invalid log ID",
+			Details: err.Error(),
+		})
+	}
+
+	err = withSets(config.DB.Preload("Exercise")).First(&log, logID).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return log, echo.NewHTTPError(http.StatusNotFound, dto.ErrorResponse{
+			Message: "Log not found",
+		})
+	} else if err != nil {
+		return log, echo.NewHTTPError(http.StatusInternalServerError, dto.ErrorResponse{
+			Message: "Failed to retrieve log",
+			Details: err.Error(),
+		})
+	}
+
+	if log.UserID != userID {
+		return log, echo.NewHTTPError(http.StatusForbidden, dto.ErrorResponse{
+			Message: "You are not authorized to access this log",
+		})
+	}
+
+	return log, nil
+}
+
+// logChanges lists the editable fields that differ between two versions of a log, weights in kg
+func logChanges(before, after model.ExerciseLog) map[string]map[string]interface{} {
+	changes := map[string]map[string]interface{}{}
+	add := func(field string, old, new interface{}) {
+		if old != new {
+			changes[field] = map[string]interface{}{"old": old, "new": new}
+		}
+	}
+
+	add("set_count", before.SetCount, after.SetCount)
+	add("rep_count", before.RepCount, after.RepCount)
+	add("weight", before.Weight, after.Weight)
+	add("duration_seconds", before.DurationSeconds, after.DurationSeconds)
+	add("distance_meters", before.DistanceMeters, after.DistanceMeters)
+	add("added_weight", before.AddedWeight, after.AddedWeight)
+	add("assisted_weight", before.AssistedWeight, after.AssistedWeight)
+	add("notes", before.Notes, after.Notes)
+	add("tempo", before.Tempo, after.Tempo)
+	add("rest_seconds", before.RestSeconds, after.RestSeconds)
+	add("perceived_effort", effortValue(before.PerceivedEffort), effortValue(after.PerceivedEffort))
+	add("round", before.Round, after.Round)
+	if !before.CreatedAt.Equal(after.CreatedAt) {
+		changes["created_at"] = map[string]interface{}{"old": before.CreatedAt, "new": after.CreatedAt}
+	}
+
+	oldSets, newSets := toLogSetResponses(before.Sets, helper.UnitKg), toLogSetResponses(after.Sets, helper.UnitKg)
+	if !reflect.DeepEqual(oldSets, newSets) {
+		changes["sets"] = map[string]interface{}{"old": oldSets, "new": newSets}
+	}
+	return changes
+}
+
+// effortValue dereferences an optional perceived effort so it compares by value
+func effortValue(effort *float64) interface{} {
+	if effort == nil {
+		return nil
+	}
+	return *effort
+}
+
+// saveLogEdit validates the edited log and stores it together with a revision of the changes
+func saveLogEdit(c echo.Context, userID uint, before, after model.ExerciseLog) error {
+	var changed bool
+	err := config.DB.Transaction(func(tx *gorm.DB) error {
+		var err error
+		changed, err = storeLogEdit(tx, userID, before, &after)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	unit, err := userWeightUnit(userID)
+	if err != nil {
+		return err
+	}
+
+	message := "Log updated successfully"
+	if !changed {
+		message = "Log unchanged"
+	}
+	return c.JSON(http.StatusOK, dto.SuccessResponse{
+		Message: message,
+		Data:    toExerciseLogDetail(after, unit),
+	})
+}
+
+// storeLogEdit validates the edited log and, when anything changed, updates it in tx with a
+// revision of the changes. It reports whether the log changed.
+func storeLogEdit(tx *gorm.DB, userID uint, before model.ExerciseLog, after *model.ExerciseLog) (bool, error) {
+	// A log keeps the catalog entry it was logged as, the exercise may have been swapped since
+	exerciseType, err := definitionType(before.DefinitionID)
+	if err != nil {
+		return false, err
+	}
+
+	if err := applyLogSets(exerciseType, after); err != nil {
+		return false, err
+	}
+
+	if err := helper.ValidateLogForType(exerciseType, logValues(*after)); err != nil {
+		return false, echo.NewHTTPError(http.StatusBadRequest, dto.ErrorResponse{
+			Message: "Invalid input for " + exerciseType + " exercise",
+			Details: err.Error(),
+		})
+	}
+
+	if err := validateLogRound(before.Exercise, after.Round); err != nil {
+		return false, err
+	}
+
+	after.Tempo = helper.NormalizeTempo(after.Tempo)
+	if err := validateLogMetadata(*after); err != nil {
+		return false, err
+	}
+
+	// Only a new timestamp is checked, so logs stored before the check can still be edited
+	if !after.CreatedAt.Equal(before.CreatedAt) {
+		if err := validateLogTime(after.CreatedAt); err != nil {
+			return false, err
+		}
+	}
+
+	after.PaceSecondsPerKm = helper.PaceSecondsPerKm(after.DurationSeconds, after.DistanceMeters)
+
+	changes := logChanges(before, *after)
+	if len(changes) == 0 {
+		return false, nil
+	}
+
+	changesJSON, err := json.Marshal(changes)
+	if err != nil {
+		return false, echo.NewHTTPError(http.StatusInternalServerError, dto.ErrorResponse{
+			Message: "Failed to record log changes",
+			Details: err.Error(),
+		})
+	}
+
+	if err := tx.Model(&model.ExerciseLog{}).Where("id = ?", after.ID).Updates(map[string]interface{}{
+		"set_count":           after.SetCount,
+		"rep_count":           after.RepCount,
+		"weight":              after.Weight,
+		"duration_seconds":    after.DurationSeconds,
+		"distance_meters":     after.DistanceMeters,
+		"pace_seconds_per_km": after.PaceSecondsPerKm,
+		"added_weight":        after.AddedWeight,
+		"assisted_weight":     after.AssistedWeight,
+		"notes":               after.Notes,
+		"tempo":               after.Tempo,
+		"rest_seconds":        after.RestSeconds,
+		"perceived_effort":    after.PerceivedEffort,
+		"round":               after.Round,
+		"created_at":          after.CreatedAt,
+	}).Error; err != nil {
+		return false, echo.NewHTTPError(http.StatusInternalServerError, dto.ErrorResponse{
+			Message: "Failed to update log",
+			Details: err.Error(),
+		})
+	}
+
+	if _, ok := changes["sets"]; ok {
+		if err := tx.Where("exercise_log_id = ?", after.ID).Delete(&model.LogSet{}).Error; err != nil {
+			return false, echo.NewHTTPError(http.StatusInternalServerError, dto.ErrorResponse{
+				Message: "Failed to update log sets",
+				Details: err.Error(),
+			})
+		}
+
+		for i := range after.Sets {
+			after.Sets[i].ID = 0
+			after.Sets[i].ExerciseLogID = after.ID
+		}
+		if len(after.Sets) > 0 {
+			if err := tx.Create(&after.Sets).Error; err != nil {
+				return false, echo.NewHTTPError(http.StatusInternalServerError, dto.ErrorResponse{
+					Message: "Failed to update log sets",
+					Details: err.Error(),
+				})
+			}
+		}
+	}
+
+	// Any edit can change which logs hold the records, so the scope is replayed
+	if err := config.RecomputeLogRecords(tx, []model.ExerciseLog{*after}); err != nil {
+		return false, echo.NewHTTPError(http.StatusInternalServerError, dto.ErrorResponse{
+			Message: "Failed to update personal records",
+			Details: err.Error(),
+		})
+	}
+
+	revision := model.ExerciseLogRevision{
+		ExerciseLogID: after.ID,
+		UserID:        userID,
+		Changes:       string(changesJSON),
+		CreatedAt:     time.Now(),
+	}
+	if err := tx.Create(&revision).Error; err != nil {
+		return false, echo.NewHTTPError(http.StatusInternalServerError, dto.ErrorResponse{
+			Message: "Failed to record log changes",
+			Details: err.Error(),
+		})
+	}
+
+	return true, nil
+}
+
+// parseTimeParam accepts RFC3339 timestamps or YYYY-MM-DD dates starting at midnight in loc;
+// dateOnly tells which one matched
+func parseTimeParam(value string, loc *time.Location) (t time.Time, dateOnly bool, err error) {
+	if t, err = time.Parse(time.RFC3339, value); err == nil {
+		return t, false, nil
+	}
+	t, err = time.ParseInLocation(dateLayout, value, loc)
+	return t, true, err
+}
+
+// newExerciseLog validates a log request for an exercise the user owns and builds the log,
+// returning it with the exercise type it was validated for
+func newExerciseLog(userID uint, exercise model.Exercise, req dto.ExerciseLogRequest) (model.ExerciseLog, string, error) {
+	if len(req.Sets) > 0 && (req.SetCount != 0 || req.RepCount != 0 || req.Weight != 0 || req.AddedWeight != 0) {
+		return model.ExerciseLog{}, "", errSetsDerivedFields
+	}
+
+	inputUnit, err := requestWeightUnit(req.WeightUnit, userID)
+	if err != nil {
+		return model.ExerciseLog{}, "", err
+	}
+
+	log := model.ExerciseLog{
+		ExerciseID:   exercise.ID,
+		UserID:       userID,
+		DefinitionID: exercise.DefinitionID,
+		SetCount:     req.SetCount,
+		RepCount:     req.RepCount,
+		Weight:       helper.ToKg(req.Weight, inputUnit),
+
+		DurationSeconds:  req.DurationSeconds,
+		DistanceMeters:   req.DistanceMeters,
+		PaceSecondsPerKm: helper.PaceSecondsPerKm(req.DurationSeconds, req.DistanceMeters),
+		AddedWeight:      helper.ToKg(req.AddedWeight, inputUnit),
+		AssistedWeight:   helper.ToKg(req.AssistedWeight, inputUnit),
+
+		Notes:           req.Notes,
+		Tempo:           helper.NormalizeTempo(req.Tempo),
+		RestSeconds:     req.RestSeconds,
+		PerceivedEffort: req.PerceivedEffort,
+
+		Round:     req.Round,
+		CreatedAt: req.CreatedAt,
+		Sets:      toLogSets(req.Sets, inputUnit),
+	}
+
+	// The server clock is the reference, clients only send a time to backdate a log
+	if log.CreatedAt.IsZero() {
+		log.CreatedAt = time.Now()
+	} else if err := validateLogTime(log.CreatedAt); err != nil {
+		return model.ExerciseLog{}, "", err
+	}
+
+	// Required fields depend on the exercise type of the catalog entry
+	exerciseType, err := exerciseTypeOf(exercise)
+	if err != nil {
+		return model.ExerciseLog{}, "", err
+	}
+
+	if err := applyLogSets(exerciseType, &log); err != nil {
+		return model.ExerciseLog{}, "", err
+	}
+
+	if err := helper.ValidateLogForType(exerciseType, logValues(log)); err != nil {
+		return model.ExerciseLog{}, "", echo.NewHTTPError(http.StatusBadRequest, dto.ErrorResponse{
+			Message: "Invalid input for " + exerciseType + " exercise",
+			Details: err.Error(),
+		})
+	}
+
+	if err := validateLogRound(exercise, req.Round); err != nil {
+		return model.ExerciseLog{}, "", err
+	}
+
+	if err := validateLogMetadata(log); err != nil {
+		return model.ExerciseLog{}, "", err
+	}
+
+	// Logs can optionally be attached to one of the user's sessions still in progress
+	if req.SessionID != 0 {
+		var session model.WorkoutSession
+		if err := config.DB.First(&session, req.SessionID).Error; err != nil {
+			return model.ExerciseLog{}, "", echo.NewHTTPError(http.StatusNotFound, dto.ErrorResponse{
+				Message: "Session not found",
+				Details: err.Error(),
+			})
+		}
+
+		if session.UserID != userID {
+			return model.ExerciseLog{}, "", echo.NewHTTPError(http.StatusForbidden, dto.ErrorResponse{
+				Message: "You are not authorized to log into this session",
+			})
+		}
+
+		if session.EndedAt != nil {
+			return model.ExerciseLog{}, "", echo.NewHTTPError(http.StatusConflict, dto.ErrorResponse{
+				Message: "Session is already finished",
+			})
+		}
+		log.SessionID = &session.ID
+	}
+
+	return log, exerciseType, nil
+}
+
 // CreateExerciseLog godoc
 // @Summary      Create an exercise log
-// @Description  Logs an exercise with set, rep, and weight
+// @Description  Logs an exercise; required fields depend on its type (strength, bodyweight, timed_hold, distance_cardio, intervals)
 // @Tags         exercise-logs
 // @Accept       json
 // @Produce      json
 // @Param        log  body  dto.ExerciseLogRequest  true  "Exercise log data"
-// @Success      201  {object}  dto.SuccessResponse
+// @Success      201  {object}  dto.SuccessResponse{data=dto.ExerciseLogResponse}
 // @Failure      400  {object}  dto.ErrorResponse
 // @Failure      401  {object}  dto.ErrorResponse
 // @Failure      403  {object}  dto.ErrorResponse
 // @Failure      404  {object}  dto.ErrorResponse
 // @Failure      500  {object}  dto.ErrorResponse
-// @Router       /exercise-logs [post]
+// @Router       /api/logs [post]
 // @Security     BearerAuth
 func CreateExerciseLog(c echo.Context) error {
 	userID, err := helper.ExtractUserID(c)
@@ -58,27 +615,56 @@ func CreateExerciseLog(c echo.Context) error {
 		})
 	}
 
-	log := model.ExerciseLog{
-		ExerciseID: req.ExerciseID,
-		UserID:     userID,
-		SetCount:   req.SetCount,
-		RepCount:   req.RepCount,
-		Weight:     req.Weight,
-		CreatedAt:  req.CreatedAt,
+	log, exerciseType, err := newExerciseLog(userID, exercise, req)
+	if err != nil {
+		return err
 	}
 
-	if err := config.DB.Create(&log).Error; err != nil {
+	var records []model.PersonalRecord
+	err = config.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&log).Error; err != nil {
+			return err
+		}
+		records, err = detectPersonalRecords(tx, exerciseType, log)
+		return err
+	})
+	if err != nil {
 		return echo.NewHTTPError(http.StatusInternalServerError, dto.ErrorResponse{
 			Message: "Failed to create log",
 			Details: err.Error(),
 		})
 	}
 
+	unit, err := userWeightUnit(userID)
+	if err != nil {
+		return err
+	}
+
 	response := dto.ExerciseLogResponse{
 		ExerciseID: log.ExerciseID,
 		SetCount:   uint(log.SetCount),
 		RepCount:   uint(log.RepCount),
-		Weight:     uint(log.Weight),
+		Weight:     helper.FromKg(log.Weight, unit),
+		WeightUnit: unit,
+		Round:      uint(log.Round),
+		SessionID:  req.SessionID,
+
+		ExerciseType:     exerciseType,
+		DurationSeconds:  log.DurationSeconds,
+		DistanceMeters:   log.DistanceMeters,
+		PaceSecondsPerKm: log.PaceSecondsPerKm,
+		AddedWeight:      helper.FromKg(log.AddedWeight, unit),
+		AssistedWeight:   helper.FromKg(log.AssistedWeight, unit),
+
+		Notes:           log.Notes,
+		Tempo:           log.Tempo,
+		RestSeconds:     log.RestSeconds,
+		PerceivedEffort: log.PerceivedEffort,
+
+		Sets: toLogSetResponses(log.Sets, unit),
+
+		IsPersonalRecord: len(records) > 0,
+		PersonalRecords:  toPersonalRecordResponses(records, unit),
 	}
 
 	return c.JSON(http.StatusCreated, dto.SuccessResponse{
@@ -99,7 +685,7 @@ func CreateExerciseLog(c echo.Context) error {
 // @Failure      403  {object}  dto.ErrorResponse
 // @Failure      404  {object}  dto.ErrorResponse
 // @Failure      500  {object}  dto.ErrorResponse
-// @Router       /exercise-logs/{id} [delete]
+// @Router       /api/logs/{id} [delete]
 // @Security     BearerAuth
 func DeleteExerciseLog(c echo.Context) error {
 	userID, err := helper.ExtractUserID(c)
@@ -131,18 +717,27 @@ func DeleteExerciseLog(c echo.Context) error {
 		})
 	}
 
-	if err := config.DB.Delete(&log).Error; err != nil {
+	err = config.DB.Transaction(func(tx *gorm.DB) error {
+		return config.DeleteLogs(tx, "id = ?", log.ID)
+	})
+	if err != nil {
 		return echo.NewHTTPError(http.StatusInternalServerError, dto.ErrorResponse{
 			Message: "Failed to delete log",
 			Details: err.Error(),
 		})
 	}
 
+	unit, err := userWeightUnit(userID)
+	if err != nil {
+		return err
+	}
+
 	response := dto.ExerciseLogResponse{
 		ExerciseID: log.ExerciseID,
 		SetCount:   uint(log.SetCount),
 		RepCount:   uint(log.RepCount),
-		Weight:     uint(log.Weight),
+		Weight:     helper.FromKg(log.Weight, unit),
+		WeightUnit: unit,
 	}
 
 	return c.JSON(http.StatusOK, dto.SuccessResponse{
@@ -150,3 +745,453 @@ func DeleteExerciseLog(c echo.Context) error {
 		Data:    response,
 	})
 }
+
+// GetExerciseLogs godoc
+// @Summary      List exercise logs
+// @Description  Lists the user's logs with filters, sorting and cursor pagination
+// @Tags         exercise-logs
+// @Produce      json
+// @Param        exercise_id  query  int     false  "Only logs of this exercise"
+// @Param        workout_id   query  int     false  "Only logs of exercises in this workout"
+// @Param        session_id   query  int     false  "Only logs of this session"
+// @Param        q            query  string  false  "Only logs whose notes match these search terms"
+// @Param        from         query  string  false  "Logged at or after (YYYY-MM-DD or RFC3339)"
+// @Param        to           query  string  false  "Logged at or before (YYYY-MM-DD inclusive or RFC3339)"
+// @Param        sort         query  string  false  "created_at, -created_at (default), weight or -weight"
+// @Param        limit        query  int     false  "Page size, defaults to 20, at most 100"
+// @Param        cursor       query  string  false  "next_cursor of the previous page"
+// @Success      200  {object}  dto.SuccessResponse{data=dto.ExerciseLogPageResponse}
+// @Failure      400  {object}  dto.ErrorResponse
+// @Failure      401  {object}  dto.ErrorResponse
+// @Failure      500  {object}  dto.ErrorResponse
+// @Router       /api/logs [get]
+// @Security     BearerAuth
+func GetExerciseLogs(c echo.Context) error {
+	userID, err := helper.ExtractUserID(c)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusUnauthorized, dto.ErrorResponse{
+			Message: "Failed to extract user information",
+			Details: err.Error(),
+		})
+	}
+
+	var exerciseID, workoutID, sessionID uint
+	limit := logsDefaultLimit
+	if err := echo.QueryParamsBinder(c).
+		Uint("exercise_id", &exerciseID).
+		Uint("workout_id", &workoutID).
+		Uint("session_id", &sessionID).
+		Int("limit", &limit).
+		BindError(); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, dto.ErrorResponse{
+			Message: "Invalid query parameters",
+			Details: err.Error(),
+		})
+	}
+	if limit <= 0 {
+		return echo.NewHTTPError(http.StatusBadRequest, dto.ErrorResponse{
+			Message: "Invalid limit",
+		})
+	}
+	if limit > logsMaxLimit {
+		limit = logsMaxLimit
+	}
+
+	sortParam := c.QueryParam("sort")
+	if sortParam == "" {
+		sortParam = "-created_at"
+	}
+	desc := strings.HasPrefix(sortParam, "-")
+	sortKey := strings.TrimPrefix(sortParam, "-")
+	sortColumn, ok := logSortColumns[sortKey]
+	if !ok {
+		return echo.NewHTTPError(http.StatusBadRequest, dto.ErrorResponse{
+			Message: "Invalid sort",
+			Details: "sort must be one of created_at, -created_at, weight, -weight",
+		})
+	}
+
+	query := withSets(config.DB.Preload("Exercise")).Where("exercise_logs.user_id = ?", userID)
+
+	if exerciseID != 0 {
+		query = query.Where("exercise_logs.exercise_id = ?", exerciseID)
+	}
+	if workoutID != 0 {
+		query = query.Where("exercise_logs.exercise_id IN (?)",
+			config.DB.Model(&model.Exercise{}).Select("id").Where("workout_id = ?", workoutID))
+	}
+	if q := strings.TrimSpace(c.QueryParam("q")); q != "" {
+		query = query.Where("exercise_logs.search_vector @@ websearch_to_tsquery(?::regconfig, ?)", config.SearchConfig, q)
+	}
+	if sessionID != 0 {
+		query = query.Where("exercise_logs.session_id = ?", sessionID)
+	}
+
+	// Dates start at midnight in the user's timezone
+	loc, err := userLocation(userID)
+	if err != nil {
+		return err
+	}
+	query, err = applyLogTimeRange(c, query, loc)
+	if err != nil {
+		return err
+	}
+
+	// Keyset pagination on (sort column, id) so pages stay stable while logs are added
+	if cursor := c.QueryParam("cursor"); cursor != "" {
+		value, lastID, err := helper.DecodeCursor(cursor)
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, dto.ErrorResponse{
+				Message: "Invalid cursor",
+				Details: err.Error(),
+			})
+		}
+
+		var cursorValue interface{}
+		if sortKey == "created_at" {
+			cursorValue, err = time.Parse(time.RFC3339Nano, value)
+		} else {
+			cursorValue, err = strconv.ParseFloat(value, 64)
+		}
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, dto.ErrorResponse{
+				Message: "Invalid cursor",
+				Details: "cursor does not match the requested sort",
+			})
+		}
+
+		op := ">"
+		if desc {
+			op = "<"
+		}
+		query = query.Where("("+sortColumn+", exercise_logs.id) "+op+" (?, ?)", cursorValue, lastID)
+	}
+
+	direction := " ASC"
+	if desc {
+		direction = " DESC"
+	}
+
+	// One extra row tells whether there is a next page
+	var logs []model.ExerciseLog
+	if err := query.
+		Order(sortColumn + direction).
+		Order("exercise_logs.id" + direction).
+		Limit(limit + 1).
+		Find(&logs).Error; err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, dto.ErrorResponse{
+			Message: "Failed to retrieve logs",
+			Details: err.Error(),
+		})
+	}
+
+	unit, err := userWeightUnit(userID)
+	if err != nil {
+		return err
+	}
+
+	response := dto.ExerciseLogPageResponse{Items: []dto.ExerciseLogDetailResponse{}}
+	if len(logs) > limit {
+		logs = logs[:limit]
+		last := logs[len(logs)-1]
+		value := last.CreatedAt.Format(time.RFC3339Nano)
+		if sortKey == "weight" {
+			value = strconv.FormatFloat(last.Weight, 'f', -1, 64)
+		}
+		response.NextCursor = helper.EncodeCursor(value, last.ID)
+	}
+	for _, l := range logs {
+		response.Items = append(response.Items, toExerciseLogDetail(l, unit))
+	}
+
+	return c.JSON(http.StatusOK, dto.SuccessResponse{
+		Message: "Success Retrieving Logs",
+		Data:    response,
+	})
+}
+
+// GetExerciseLogByID godoc
+// @Summary      Get exercise log by ID
+// @Description  Retrieves a single log of the authenticated user
+// @Tags         exercise-logs
+// @Produce      json
+// @Param        id  path  int  true  "Log ID"
+// @Success      200  {object}  dto.SuccessResponse{data=dto.ExerciseLogDetailResponse}
+// @Failure      400  {object}  dto.ErrorResponse
+// @Failure      401  {object}  dto.ErrorResponse
+// @Failure      403  {object}  dto.ErrorResponse
+// @Failure      404  {object}  dto.ErrorResponse
+// @Failure      500  {object}  dto.ErrorResponse
+// @Router       /api/logs/{id} [get]
+// @Security     BearerAuth
+func GetExerciseLogByID(c echo.Context) error {
+	userID, err := helper.ExtractUserID(c)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusUnauthorized, dto.ErrorResponse{
+			Message: "Failed to extract user information",
+			Details: err.Error(),
+		})
+	}
+
+	logID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, dto.ErrorResponse{
+			Message: "Invalid log ID",
+			Details: err.Error(),
+		})
+	}
+
+	var log model.ExerciseLog
+	err = withSets(config.DB.Preload("Exercise")).First(&log, logID).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return echo.NewHTTPError(http.StatusNotFound, dto.ErrorResponse{
+			Message: "Log not found",
+		})
+	} else if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, dto.ErrorResponse{
+			Message: "Failed to retrieve log",
+			Details: err.Error(),
+		})
+	}
+
+	if log.UserID != userID {
+		return echo.NewHTTPError(http.StatusForbidden, dto.ErrorResponse{
+			Message: "You are not authorized to view this log",
+		})
+	}
+
+	unit, err := userWeightUnit(userID)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, dto.SuccessResponse{
+		Message: "Success Retrieving Log",
+		Data:    toExerciseLogDetail(log, unit),
+	})
+}
+
+// UpdateExerciseLog godoc
+// @Summary      Replace an exercise log
+// @Description  Replaces the values of a log, keeping its timestamp unless created_at is sent; the change is kept in the log history
+// @Tags         exercise-logs
+// @Accept       json
+// @Produce      json
+// @Param        id   path  int                           true  "Log ID"
+// @Param        log  body  dto.ExerciseLogUpdateRequest  true  "Log values"
+// @Success      200  {object}  dto.SuccessResponse{data=dto.ExerciseLogDetailResponse}
+// @Failure      400  {object}  dto.ErrorResponse
+// @Failure      401  {object}  dto.ErrorResponse
+// @Failure      403  {object}  dto.ErrorResponse
+// @Failure      404  {object}  dto.ErrorResponse
+// @Failure      500  {object}  dto.ErrorResponse
+// @Router       /api/logs/{id} [put]
+// @Security     BearerAuth
+func UpdateExerciseLog(c echo.Context) error {
+	userID, err := helper.ExtractUserID(c)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusUnauthorized, dto.ErrorResponse{
+			Message: "Unauthorized",
+			Details: err.Error(),
+		})
+	}
+
+	log, err := findOwnedLog(c, userID)
+	if err != nil {
+		return err
+	}
+
+	var req dto.ExerciseLogUpdateRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, dto.ErrorResponse{
+			Message: "Invalid request body",
+			Details: err.Error(),
+		})
+	}
+
+	updated, err := replacedLog(userID, log, req)
+	if err != nil {
+		return err
+	}
+
+	return saveLogEdit(c, userID, log, updated)
+}
+
+// replacedLog returns log with every value replaced by the PUT request, weights converted to kg
+func replacedLog(userID uint, log model.ExerciseLog, req dto.ExerciseLogUpdateRequest) (model.ExerciseLog, error) {
+	if len(req.Sets) > 0 && (req.SetCount != 0 || req.RepCount != 0 || req.Weight != 0 || req.AddedWeight != 0) {
+		return log, errSetsDerivedFields
+	}
+
+	inputUnit, err := requestWeightUnit(req.WeightUnit, userID)
+	if err != nil {
+		return log, err
+	}
+
+	updated := log
+	updated.SetCount = req.SetCount
+	updated.RepCount = req.RepCount
+	updated.Weight = helper.ToKg(req.Weight, inputUnit)
+	updated.DurationSeconds = req.DurationSeconds
+	updated.DistanceMeters = req.DistanceMeters
+	updated.AddedWeight = helper.ToKg(req.AddedWeight, inputUnit)
+	updated.AssistedWeight = helper.ToKg(req.AssistedWeight, inputUnit)
+	updated.Notes = req.Notes
+	updated.Tempo = req.Tempo
+	updated.RestSeconds = req.RestSeconds
+	updated.PerceivedEffort = req.PerceivedEffort
+	updated.Round = req.Round
+	if !req.CreatedAt.IsZero() {
+		updated.CreatedAt = req.CreatedAt
+	}
+	updated.Sets = toLogSets(req.Sets, inputUnit)
+	return updated, nil
+}
+
+// PatchExerciseLog godoc
+// @Summary      Partially update an exercise log
+// @Description  Updates only the provided fields of a log; the change is kept in the log history
+// @Tags         exercise-logs
+// @Accept       json
+// @Produce      json
+// @Param        id   path  int                          true  "Log ID"
+// @Param        log  body  dto.ExerciseLogPatchRequest  true  "Fields to update"
+// @Success      200  {object}  dto.SuccessResponse{data=dto.ExerciseLogDetailResponse}
+// @Failure      400  {object}  dto.ErrorResponse
+// @Failure      401  {object}  dto.ErrorResponse
+// @Failure      403  {object}  dto.ErrorResponse
+// @Failure      404  {object}  dto.ErrorResponse
+// @Failure      500  {object}  dto.ErrorResponse
+// @Router       /api/logs/{id} [patch]
+// @Security     BearerAuth
+func PatchExerciseLog(c echo.Context) error {
+	userID, err := helper.ExtractUserID(c)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusUnauthorized, dto.ErrorResponse{
+			Message: "Unauthorized",
+			Details: err.Error(),
+		})
+	}
+
+	log, err := findOwnedLog(c, userID)
+	if err != nil {
+		return err
+	}
+
+	var req dto.ExerciseLogPatchRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, dto.ErrorResponse{
+			Message: "Invalid request body",
+			Details: err.Error(),
+		})
+	}
+
+	inputUnit, err := requestWeightUnit(req.WeightUnit, userID)
+	if err != nil {
+		return err
+	}
+
+	// Logs with sets only change their aggregate through the sets
+	hasSets := len(req.Sets) > 0 || (req.Sets == nil && len(log.Sets) > 0)
+	if hasSets && (req.SetCount != nil || req.RepCount != nil || req.Weight != nil || req.AddedWeight != nil) {
+		return errSetsDerivedFields
+	}
+
+	updated := log
+	if req.SetCount != nil {
+		updated.SetCount = *req.SetCount
+	}
+	if req.RepCount != nil {
+		updated.RepCount = *req.RepCount
+	}
+	if req.Weight != nil {
+		updated.Weight = helper.ToKg(*req.Weight, inputUnit)
+	}
+	if req.DurationSeconds != nil {
+		updated.DurationSeconds = *req.DurationSeconds
+	}
+	if req.DistanceMeters != nil {
+		updated.DistanceMeters = *req.DistanceMeters
+	}
+	if req.AddedWeight != nil {
+		updated.AddedWeight = helper.ToKg(*req.AddedWeight, inputUnit)
+	}
+	if req.AssistedWeight != nil {
+		updated.AssistedWeight = helper.ToKg(*req.AssistedWeight, inputUnit)
+	}
+	if req.Notes != nil {
+		updated.Notes = *req.Notes
+	}
+	if req.Tempo != nil {
+		updated.Tempo = *req.Tempo
+	}
+	if req.RestSeconds != nil {
+		updated.RestSeconds = *req.RestSeconds
+	}
+	if req.PerceivedEffort != nil {
+		updated.PerceivedEffort = req.PerceivedEffort
+	}
+	if req.Round != nil {
+		updated.Round = *req.Round
+	}
+	if req.CreatedAt != nil {
+		updated.CreatedAt = *req.CreatedAt
+	}
+	if req.Sets != nil {
+		updated.Sets = toLogSets(req.Sets, inputUnit)
+	}
+
+	return saveLogEdit(c, userID, log, updated)
+}
+
+// GetExerciseLogHistory godoc
+// @Summary      Get the change history of a log
+// @Description  Lists every edit made to a log, oldest first, with old and new values of the changed fields
+// @Tags         exercise-logs
+// @Produce      json
+// @Param        id  path  int  true  "Log ID"
+// @Success      200  {object}  dto.SuccessResponse{data=[]dto.ExerciseLogRevisionResponse}
+// @Failure      400  {object}  dto.ErrorResponse
+// @Failure      401  {object}  dto.ErrorResponse
+// @Failure      403  {object}  dto.ErrorResponse
+// @Failure      404  {object}  dto.ErrorResponse
+// @Failure      500  {object}  dto.ErrorResponse
+// @Router       /api/logs/{id}/history [get]
+// @Security     BearerAuth
+func GetExerciseLogHistory(c echo.Context) error {
+	userID, err := helper.ExtractUserID(c)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusUnauthorized, dto.ErrorResponse{
+			Message: "Failed to extract user information",
+			Details: err.Error(),
+		})
+	}
+
+	log, err := findOwnedLog(c, userID)
+	if err != nil {
+		return err
+	}
+
+	var revisions []model.ExerciseLogRevision
+	if err := config.DB.Where("exercise_log_id = ?", log.ID).Order("created_at, id").Find(&revisions).Error; err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, dto.ErrorResponse{
+			Message: "Failed to retrieve log history",
+			Details: err.Error(),
+		})
+	}
+
+	response := []dto.ExerciseLogRevisionResponse{}
+	for _, r := range revisions {
+		response = append(response, dto.ExerciseLogRevisionResponse{
+			ID:        r.ID,
+			UserID:    r.UserID,
+			Changes:   json.RawMessage(r.Changes),
+			CreatedAt: r.CreatedAt,
+		})
+	}
+
+	return c.JSON(http.StatusOK, dto.SuccessResponse{
+		Message: "Success Retrieving Log History",
+		Data:    response,
+	})
+}
@@ -0,0 +1,169 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+	"p2gc3/config"
+	"p2gc3/dto"
+	helper "p2gc3/helpers"
+	"p2gc3/model"
+	"time"
+
+	"github.com/labstack/echo/v4"
+)
+
+// exportFlushRows is how many rows are written between flushes to the client
+const exportFlushRows = 500
+
+// exportSelect reads the columns of helper.ExportRow, weights still in kg
+const exportSelect = `exercise_logs.id AS log_id,
+	exercise_logs.created_at AS logged_at,
+	workouts.id AS workout_id,
+	workouts.name AS workout_name,
+	exercises.id AS exercise_id,
+	exercises.name AS exercise_name,
+	COALESCE(exercise_definitions.exercise_type, 'strength') AS exercise_type,
+	exercise_logs.session_id,
+	exercise_logs.set_count,
+	exercise_logs.rep_count,
+	exercise_logs.weight,
+	exercise_logs.added_weight,
+	exercise_logs.assisted_weight,
+	exercise_logs.duration_seconds,
+	exercise_logs.distance_meters,
+	exercise_logs.pace_seconds_per_km,
+	exercise_logs.rest_seconds,
+	exercise_logs.tempo,
+	exercise_logs.perceived_effort,
+	exercise_logs.notes`
+
+// ExportLogs godoc
+// @Summary      Export exercise logs
+// @Description  Streams the user's logs with their workout and exercise as CSV, JSON or XLSX, oldest first. Every format has the same columns in this order: log_id, logged_at (RFC3339, UTC), workout_id, workout_name, exercise_id, exercise_name, exercise_type, session_id, set_count, rep_count, weight, added_weight, assisted_weight, weight_unit, duration_seconds, distance_meters, pace_seconds_per_km, rest_seconds, tempo, perceived_effort, notes. New columns are only added at the end. CSV text cells starting with =, +, -, @, a tab or a carriage return are prefixed with ' so spreadsheets do not run them as formulas.
+// @Tags         export
+// @Produce      text/csv
+// @Produce      json
+// @Produce      application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+// @Param        format       query  string  false  "csv (default), json or xlsx"
+// @Param        exercise_id  query  int     false  "Only logs of this exercise"
+// @Param        workout_id   query  int     false  "Only logs of exercises in this workout"
+// @Param        from         query  string  false  "Logged at or after (YYYY-MM-DD or RFC3339)"
+// @Param        to           query  string  false  "Logged at or before (YYYY-MM-DD inclusive or RFC3339)"
+// @Param        weight_unit  query  string  false  "Unit of the weight columns, kg or lb, defaults to the preferred unit"
+// @Success      200  {array}   helper.ExportRow
+// @Failure      400  {object}  dto.ErrorResponse
+// @Failure      401  {object}  dto.ErrorResponse
+// @Failure      500  {object}  dto.ErrorResponse
+// @Router       /api/export [get]
+// @Security     BearerAuth
+func ExportLogs(c echo.Context) error {
+	userID, err := helper.ExtractUserID(c)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusUnauthorized, dto.ErrorResponse{
+			Message: "Failed to extract user information",
+			Details: err.Error(),
+		})
+	}
+
+	format := c.QueryParam("format")
+	if format == "" {
+		format = helper.ExportCSV
+	}
+	if format != helper.ExportCSV && format != helper.ExportJSON && format != helper.ExportXLSX {
+		return echo.NewHTTPError(http.StatusBadRequest, dto.ErrorResponse{
+			Message: "Invalid format",
+			Details: "format must be one of csv, json, xlsx",
+		})
+	}
+
+	var exerciseID, workoutID uint
+	if err := echo.QueryParamsBinder(c).
+		Uint("exercise_id", &exerciseID).
+		Uint("workout_id", &workoutID).
+		BindError(); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, dto.ErrorResponse{
+			Message: "Invalid query parameters",
+			Details: err.Error(),
+		})
+	}
+
+	unit, err := requestWeightUnit(c.QueryParam("weight_unit"), userID)
+	if err != nil {
+		return err
+	}
+
+	query := config.DB.Model(&model.ExerciseLog{}).
+		Select(exportSelect).
+		Joins("JOIN exercises ON exercises.id = exercise_logs.exercise_id").
+		Joins("JOIN workouts ON workouts.id = exercises.workout_id").
+		Joins("LEFT JOIN exercise_definitions ON exercise_definitions.id = exercise_logs.definition_id").
+		Where("exercise_logs.user_id = ?", userID)
+
+	if exerciseID != 0 {
+		query = query.Where("exercise_logs.exercise_id = ?", exerciseID)
+	}
+	if workoutID != 0 {
+		query = query.Where("exercises.workout_id = ?", workoutID)
+	}
+
+	// Dates start at midnight in the user's timezone
+	loc, err := userLocation(userID)
+	if err != nil {
+		return err
+	}
+	query, err = applyLogTimeRange(c, query, loc)
+	if err != nil {
+		return err
+	}
+
+	// Rows reads the result through a cursor, so only one row is in memory at a time
+	rows, err := query.Order("exercise_logs.created_at, exercise_logs.id").Rows()
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, dto.ErrorResponse{
+			Message: "Failed to export logs",
+			Details: err.Error(),
+		})
+	}
+	defer rows.Close()
+
+	res := c.Response()
+	res.Header().Set(echo.HeaderContentType, helper.ExportContentType(format))
+	res.Header().Set(echo.HeaderContentDisposition,
+		fmt.Sprintf(`attachment; filename="logs-%s.%s"`, time.Now().In(loc).Format("20060102"), format))
+	res.WriteHeader(http.StatusOK)
+
+	// The status is sent, errors from here on end the download early and are only logged
+	writer, err := helper.NewExportWriter(format, res)
+	if err != nil {
+		return err
+	}
+
+	written := 0
+	for rows.Next() {
+		var row helper.ExportRow
+		if err := config.DB.ScanRows(rows, &row); err != nil {
+			return err
+		}
+		row.Weight = helper.FromKg(row.Weight, unit)
+		row.AddedWeight = helper.FromKg(row.AddedWeight, unit)
+		row.AssistedWeight = helper.FromKg(row.AssistedWeight, unit)
+		row.WeightUnit = unit
+
+		if err := writer.WriteRow(row); err != nil {
+			return err
+		}
+
+		written++
+		if written%exportFlushRows == 0 {
+			if err := writer.Flush(); err != nil {
+				return err
+			}
+			res.Flush()
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	return writer.Close()
+}
@@ -0,0 +1,795 @@
+package handler
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"p2gc3/config"
+	"p2gc3/dto"
+	helper "p2gc3/helpers"
+	"p2gc3/model"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"gorm.io/gorm"
+)
+
+const (
+	importMaxBytes  = 10 << 20
+	importMaxRows   = 50000
+	importBatchSize = 500
+
+	// importedWorkoutDescription is set on workouts created by an import
+	importedWorkoutDescription = "Imported"
+
+	// importSourceCSV is the source of generic CSV imports, app imports use the app name
+	importSourceCSV = "csv"
+)
+
+// importedLog is a parsed log waiting to be attached to its workout and exercise
+type importedLog struct {
+	Line     int // first line of the log in the file
+	Rows     int // lines the log was built from, zero means one
+	Workout  string
+	Exercise string
+	Log      model.ExerciseLog // weights in kg
+
+	// AppSets are the set rows of a tracking app export, turned into Log once the exercise type is known
+	AppSets []helper.AppSet
+}
+
+// logImport is a parsed import file ready to be validated and inserted
+type logImport struct {
+	Source    string
+	Logs      []importedLog
+	RowErrors []dto.ImportRowError // rows that already failed to parse
+	RowsTotal int
+	DryRun    bool
+	Dedupe    bool // skip logs already recorded for the same exercise at the same time
+}
+
+// importTarget is the workout and exercise imported logs with the same names go to,
+// the IDs are zero until the import creates them
+type importTarget struct {
+	WorkoutName  string
+	ExerciseName string
+	WorkoutID    uint
+	Exercise     model.Exercise
+	ExerciseType string
+}
+
+func importKey(workout, exercise string) string {
+	return strings.ToLower(strings.TrimSpace(workout)) + "\x00" + strings.ToLower(strings.TrimSpace(exercise))
+}
+
+// readImportFile opens the uploaded CSV of an import request and returns its header and records
+func readImportFile(c echo.Context) ([]string, [][]string, error) {
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		return nil, nil, echo.NewHTTPError(http.StatusBadRequest, dto.ErrorResponse{
+			Message: "Invalid input: multipart field file is required",
+			Details: err.Error(),
+		})
+	}
+
+	if fileHeader.Size > importMaxBytes {
+		return nil, nil, echo.NewHTTPError(http.StatusRequestEntityTooLarge, dto.ErrorResponse{
+			Message: "File is too large",
+			Details: map[string]int64{"max_bytes": importMaxBytes, "size_bytes": fileHeader.Size},
+		})
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		return nil, nil, echo.NewHTTPError(http.StatusBadRequest, dto.ErrorResponse{
+			Message: "Invalid input: failed to read uploaded file",
+			Details: err.Error(),
+		})
+	}
+	defer file.Close()
+
+	reader := csv.NewReader(file)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, nil, echo.NewHTTPError(http.StatusBadRequest, dto.ErrorResponse{
+			Message: "Invalid CSV: missing header row",
+			Details: err.Error(),
+		})
+	}
+	// Spreadsheet exports often start with a byte order mark
+	header[0] = strings.TrimPrefix(header[0], "\ufeff")
+
+	var records [][]string
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, nil, echo.NewHTTPError(http.StatusBadRequest, dto.ErrorResponse{
+				Message: "Invalid CSV",
+				Details: err.Error(),
+			})
+		}
+		if len(records) == importMaxRows {
+			return nil, nil, echo.NewHTTPError(http.StatusRequestEntityTooLarge, dto.ErrorResponse{
+				Message: "Too many rows",
+				Details: map[string]int{"max_rows": importMaxRows},
+			})
+		}
+		records = append(records, record)
+	}
+
+	return header, records, nil
+}
+
+// inferImportTypes guesses the exercise type of every workout/exercise pair from its rows, for the
+// exercises without a catalog entry. Rows without reps are cardio when they have a distance and
+// timed holds when they only have a duration, anything else is strength.
+func inferImportTypes(logs []importedLog) map[string]string {
+	type fields struct{ reps, distance, duration bool }
+	seen := map[string]*fields{}
+	for _, l := range logs {
+		key := importKey(l.Workout, l.Exercise)
+		f := seen[key]
+		if f == nil {
+			f = &fields{}
+			seen[key] = f
+		}
+
+		f.reps = f.reps || l.Log.RepCount > 0
+		f.distance = f.distance || l.Log.DistanceMeters > 0
+		f.duration = f.duration || l.Log.DurationSeconds > 0
+		for _, set := range l.AppSets {
+			f.reps = f.reps || set.RepCount > 0
+			f.distance = f.distance || set.DistanceMeters > 0
+			f.duration = f.duration || set.DurationSeconds > 0
+		}
+	}
+
+	types := map[string]string{}
+	for key, f := range seen {
+		switch {
+		case f.reps:
+			types[key] = model.TypeStrength
+		case f.distance:
+			types[key] = model.TypeDistanceCardio
+		case f.duration:
+			types[key] = model.TypeTimedHold
+		default:
+			types[key] = model.TypeStrength
+		}
+	}
+	return types
+}
+
+// resolveImportTargets matches every workout/exercise name pair of the import with the user's
+// existing workouts and exercises, and finds the exercise type of the ones to create
+func resolveImportTargets(userID uint, logs []importedLog) (map[string]*importTarget, error) {
+	var workouts []model.Workout
+	if err := config.DB.Preload("Exercises").Where("user_id = ?", userID).Order("id").Find(&workouts).Error; err != nil {
+		return nil, err
+	}
+
+	// First workout wins when the user has several with the same name
+	workoutsByName := map[string]model.Workout{}
+	for _, w := range workouts {
+		key := strings.ToLower(strings.TrimSpace(w.Name))
+		if _, ok := workoutsByName[key]; !ok {
+			workoutsByName[key] = w
+		}
+	}
+
+	inferred := inferImportTypes(logs)
+	types := map[uint]string{}
+	targets := map[string]*importTarget{}
+	for _, l := range logs {
+		key := importKey(l.Workout, l.Exercise)
+		if _, ok := targets[key]; ok {
+			continue
+		}
+		target := &importTarget{WorkoutName: l.Workout, ExerciseName: l.Exercise, ExerciseType: model.TypeStrength}
+		targets[key] = target
+
+		var definitionID *uint
+		if w, ok := workoutsByName[strings.ToLower(strings.TrimSpace(l.Workout))]; ok {
+			target.WorkoutID = w.ID
+			for _, e := range w.Exercises {
+				if strings.EqualFold(strings.TrimSpace(e.Name), strings.TrimSpace(l.Exercise)) {
+					target.Exercise = e
+					definitionID = e.DefinitionID
+					break
+				}
+			}
+		}
+
+		// Exercises to create will be linked to the catalog entry with the same name,
+		// or a new one of the type their rows look like
+		if target.Exercise.ID == 0 {
+			target.ExerciseType = inferred[key]
+			var def model.ExerciseDefinition
+			err := config.DB.
+				Where("(user_id IS NULL OR user_id = ?) AND LOWER(TRIM(name)) = ?", userID, strings.ToLower(strings.TrimSpace(l.Exercise))).
+				Order("user_id NULLS FIRST").
+				First(&def).Error
+			if err == nil {
+				definitionID = &def.ID
+				types[def.ID] = def.ExerciseType
+			} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil, err
+			}
+		}
+
+		if definitionID != nil {
+			t, ok := types[*definitionID]
+			if !ok {
+				var def model.ExerciseDefinition
+				if err := config.DB.First(&def, *definitionID).Error; err != nil {
+					return nil, err
+				}
+				t = def.ExerciseType
+				types[def.ID] = t
+			}
+			target.ExerciseType = t
+		}
+	}
+	return targets, nil
+}
+
+// buildLogFromAppSets fills the log of an app export from its set rows, the way its exercise type records them
+func buildLogFromAppSets(exerciseType string, l *importedLog) error {
+	working := 0
+	for _, set := range l.AppSets {
+		if set.SetType != model.SetTypeWarmup {
+			working++
+		}
+	}
+
+	switch exerciseType {
+	case model.TypeTimedHold:
+		// The longest hold, like the heaviest set of a strength exercise
+		l.Log.SetCount = working
+		for _, set := range l.AppSets {
+			if set.SetType != model.SetTypeWarmup && set.DurationSeconds > l.Log.DurationSeconds {
+				l.Log.DurationSeconds = set.DurationSeconds
+			}
+		}
+
+	case model.TypeDistanceCardio:
+		for _, set := range l.AppSets {
+			l.Log.DistanceMeters += set.DistanceMeters
+			l.Log.DurationSeconds += set.DurationSeconds
+		}
+
+	case model.TypeIntervals:
+		// The average work per interval
+		l.Log.SetCount = working
+		total := 0
+		for _, set := range l.AppSets {
+			if set.SetType != model.SetTypeWarmup {
+				total += set.DurationSeconds
+			}
+		}
+		if working > 0 {
+			l.Log.DurationSeconds = total / working
+		}
+
+	default:
+		l.Log.Sets = nil
+		for _, set := range l.AppSets {
+			l.Log.Sets = append(l.Log.Sets, model.LogSet{
+				Position: len(l.Log.Sets) + 1,
+				SetType:  set.SetType,
+				RepCount: set.RepCount,
+				Weight:   set.WeightKg,
+				RPE:      set.RPE,
+			})
+		}
+		return deriveFromSets(exerciseType, &l.Log)
+	}
+	return nil
+}
+
+// duplicateKey identifies a log by exercise and time, the way app imports are deduplicated
+func duplicateKey(exerciseID uint, at time.Time) string {
+	return strconv.FormatUint(uint64(exerciseID), 10) + "@" + strconv.FormatInt(at.Unix(), 10)
+}
+
+// findDuplicateLogs returns the duplicate keys of the logs already recorded for the existing
+// exercises of the import, within the time range of the imported logs
+func findDuplicateLogs(userID uint, logs []importedLog, targets map[string]*importTarget) (map[string]bool, error) {
+	duplicates := map[string]bool{}
+
+	var exerciseIDs []uint
+	for _, t := range targets {
+		if t.Exercise.ID != 0 {
+			exerciseIDs = append(exerciseIDs, t.Exercise.ID)
+		}
+	}
+	if len(exerciseIDs) == 0 || len(logs) == 0 {
+		return duplicates, nil
+	}
+
+	from, to := logs[0].Log.CreatedAt, logs[0].Log.CreatedAt
+	for _, l := range logs {
+		if l.Log.CreatedAt.Before(from) {
+			from = l.Log.CreatedAt
+		}
+		if l.Log.CreatedAt.After(to) {
+			to = l.Log.CreatedAt
+		}
+	}
+
+	var existing []model.ExerciseLog
+	if err := config.DB.Select("exercise_id", "created_at").
+		Where("user_id = ? AND exercise_id IN ? AND created_at BETWEEN ? AND ?", userID, exerciseIDs, from, to).
+		Find(&existing).Error; err != nil {
+		return nil, err
+	}
+
+	for _, e := range existing {
+		duplicates[duplicateKey(e.ExerciseID, e.CreatedAt)] = true
+	}
+	return duplicates, nil
+}
+
+// runLogImport validates the imported logs against their exercise types and, unless it is a
+// dry run or a row is invalid, creates the missing workouts and exercises and inserts the logs
+// in one transaction.
+func runLogImport(c echo.Context, userID uint, imp logImport) error {
+	logs, rowErrors, dryRun := imp.Logs, imp.RowErrors, imp.DryRun
+	invalidRows := len(rowErrors)
+
+	targets, err := resolveImportTargets(userID, logs)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, dto.ErrorResponse{
+			Message: "Failed to resolve workouts and exercises",
+			Details: err.Error(),
+		})
+	}
+
+	now := time.Now()
+	for i := range logs {
+		l := &logs[i]
+		target := targets[importKey(l.Workout, l.Exercise)]
+
+		var problems []string
+		if len(l.AppSets) > 0 {
+			if err := buildLogFromAppSets(target.ExerciseType, l); err != nil {
+				problems = append(problems, err.Error())
+			}
+		} else if target.ExerciseType == model.TypeBodyweight {
+			// The weight column of bodyweight exercises is the load added on top of the body
+			l.Log.AddedWeight, l.Log.Weight = l.Log.Weight, 0
+		}
+
+		if err := helper.ValidateLogTime(l.Log.CreatedAt, now); err != nil {
+			problems = append(problems, err.Error())
+		}
+		if err := helper.ValidateLogForType(target.ExerciseType, logValues(l.Log)); err != nil {
+			problems = append(problems, err.Error()+" ("+target.ExerciseType+" exercise)")
+		}
+		if err := helper.ValidateLogMetadata(helper.LogMetadata{
+			Notes:           l.Log.Notes,
+			Tempo:           l.Log.Tempo,
+			RestSeconds:     l.Log.RestSeconds,
+			PerceivedEffort: l.Log.PerceivedEffort,
+		}); err != nil {
+			problems = append(problems, err.Error())
+		}
+		if len(problems) > 0 {
+			rowErrors = append(rowErrors, dto.ImportRowError{Row: l.Line, Errors: problems})
+			invalidRows += max(l.Rows, 1)
+		}
+	}
+
+	sort.Slice(rowErrors, func(i, j int) bool { return rowErrors[i].Row < rowErrors[j].Row })
+
+	if rowErrors == nil {
+		rowErrors = []dto.ImportRowError{}
+	}
+
+	response := dto.ImportLogsResponse{
+		Source:      imp.Source,
+		DryRun:      dryRun,
+		RowsTotal:   imp.RowsTotal,
+		RowsInvalid: invalidRows,
+		RowsValid:   imp.RowsTotal - invalidRows,
+		Errors:      rowErrors,
+	}
+
+	if imp.Dedupe {
+		duplicates, err := findDuplicateLogs(userID, logs, targets)
+		if err != nil {
+			return echo.NewHTTPError(http.StatusInternalServerError, dto.ErrorResponse{
+				Message: "Failed to check for already imported logs",
+				Details: err.Error(),
+			})
+		}
+
+		kept := make([]importedLog, 0, len(logs))
+		for _, l := range logs {
+			target := targets[importKey(l.Workout, l.Exercise)]
+			if target.Exercise.ID != 0 && duplicates[duplicateKey(target.Exercise.ID, l.Log.CreatedAt)] {
+				response.DuplicatesSkipped++
+				continue
+			}
+			kept = append(kept, l)
+		}
+		logs = kept
+	}
+
+	newWorkouts := map[string]uint{}
+	for _, t := range targets {
+		if t.WorkoutID == 0 {
+			newWorkouts[strings.ToLower(strings.TrimSpace(t.WorkoutName))] = 0
+		}
+		if t.Exercise.ID == 0 {
+			response.ExercisesCreated++
+		}
+	}
+	response.WorkoutsCreated = len(newWorkouts)
+
+	if len(rowErrors) > 0 && !dryRun {
+		return echo.NewHTTPError(http.StatusUnprocessableEntity, dto.ErrorResponse{
+			Message: "Import has invalid rows, nothing was imported",
+			Details: response,
+		})
+	}
+	if dryRun {
+		return c.JSON(http.StatusOK, dto.SuccessResponse{
+			Message: "Dry run completed, nothing was imported",
+			Data:    response,
+		})
+	}
+
+	err = config.DB.Transaction(func(tx *gorm.DB) error {
+		for _, t := range targets {
+			if t.WorkoutID == 0 {
+				key := strings.ToLower(strings.TrimSpace(t.WorkoutName))
+				if id := newWorkouts[key]; id != 0 {
+					t.WorkoutID = id
+				} else {
+					workout := model.Workout{
+						Name:        strings.TrimSpace(t.WorkoutName),
+						Description: importedWorkoutDescription,
+						UserID:      userID,
+					}
+					if err := tx.Create(&workout).Error; err != nil {
+						return err
+					}
+					t.WorkoutID = workout.ID
+					newWorkouts[key] = workout.ID
+				}
+			}
+
+			if t.Exercise.ID == 0 {
+				def, err := config.FindOrCreateDefinition(tx, userID, t.ExerciseName, "", t.ExerciseType)
+				if err != nil {
+					return err
+				}
+				t.Exercise = model.Exercise{
+					WorkoutID:    t.WorkoutID,
+					Name:         strings.TrimSpace(t.ExerciseName),
+					Description:  def.Description,
+					DefinitionID: &def.ID,
+				}
+				if err := tx.Create(&t.Exercise).Error; err != nil {
+					return err
+				}
+			}
+		}
+
+		rows := make([]model.ExerciseLog, 0, len(logs))
+		for _, l := range logs {
+			target := targets[importKey(l.Workout, l.Exercise)]
+			log := l.Log
+			log.UserID = userID
+			log.ExerciseID = target.Exercise.ID
+			log.DefinitionID = target.Exercise.DefinitionID
+			log.PaceSecondsPerKm = helper.PaceSecondsPerKm(log.DurationSeconds, log.DistanceMeters)
+			rows = append(rows, log)
+		}
+		if len(rows) == 0 {
+			return nil
+		}
+		if err := tx.CreateInBatches(&rows, importBatchSize).Error; err != nil {
+			return err
+		}
+		// Imported logs can be older than the ones the current records were set by
+		return config.RecomputeLogRecords(tx, rows)
+	})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, dto.ErrorResponse{
+			Message: "Failed to import logs",
+			Details: err.Error(),
+		})
+	}
+
+	response.LogsCreated = len(logs)
+	return c.JSON(http.StatusCreated, dto.SuccessResponse{
+		Message: "Logs imported successfully",
+		Data:    response,
+	})
+}
+
+// parseDryRun reads the dry_run flag from the query string or the form
+func parseDryRun(c echo.Context) (bool, error) {
+	v := c.FormValue("dry_run")
+	if v == "" {
+		return false, nil
+	}
+	dryRun, err := strconv.ParseBool(v)
+	if err != nil {
+		return false, echo.NewHTTPError(http.StatusBadRequest, dto.ErrorResponse{
+			Message: "Invalid dry_run",
+			Details: err.Error(),
+		})
+	}
+	return dryRun, nil
+}
+
+// ImportLogs godoc
+// @Summary      Import exercise logs from CSV
+// @Description  Imports one log per CSV row. Columns are mapped to date, workout, exercise, set_count, rep_count, weight, duration_seconds, distance_meters, notes, tempo, rest_seconds and perceived_effort; unmapped fields use the column with the field name. Missing workouts and exercises are created. Nothing is imported when a row is invalid.
+// @Tags         import
+// @Accept       multipart/form-data
+// @Produce      json
+// @Param        file         formData  file    true   "CSV file with a header row"
+// @Param        mapping      formData  string  false  "JSON object from field to CSV column, e.g. {\"date\":\"Day\",\"weight\":\"Load\"}"
+// @Param        weight_unit  formData  string  false  "Unit of the weight column, kg or lb, defaults to the preferred unit"
+// @Param        dry_run      formData  bool    false  "Validate and report without importing"
+// @Success      200  {object}  dto.SuccessResponse{data=dto.ImportLogsResponse}
+// @Success      201  {object}  dto.SuccessResponse{data=dto.ImportLogsResponse}
+// @Failure      400  {object}  dto.ErrorResponse
+// @Failure      401  {object}  dto.ErrorResponse
+// @Failure      413  {object}  dto.ErrorResponse
+// @Failure      422  {object}  dto.ErrorResponse{details=dto.ImportLogsResponse}
+// @Failure      500  {object}  dto.ErrorResponse
+// @Router       /api/import/logs [post]
+// @Security     BearerAuth
+func ImportLogs(c echo.Context) error {
+	userID, err := helper.ExtractUserID(c)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusUnauthorized, dto.ErrorResponse{
+			Message: "Failed to extract user information",
+			Details: err.Error(),
+		})
+	}
+
+	dryRun, err := parseDryRun(c)
+	if err != nil {
+		return err
+	}
+
+	mapping := map[string]string{}
+	if m := c.FormValue("mapping"); m != "" {
+		if err := json.Unmarshal([]byte(m), &mapping); err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, dto.ErrorResponse{
+				Message: "Invalid mapping: expected a JSON object from field to column",
+				Details: err.Error(),
+			})
+		}
+	}
+
+	unit, err := requestWeightUnit(c.FormValue("weight_unit"), userID)
+	if err != nil {
+		return err
+	}
+
+	header, records, err := readImportFile(c)
+	if err != nil {
+		return err
+	}
+
+	columns, err := helper.ResolveImportColumns(header, mapping)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, dto.ErrorResponse{
+			Message: "Invalid mapping",
+			Details: map[string]interface{}{"error": err.Error(), "fields": helper.ImportFields},
+		})
+	}
+
+	// Dates without a zone are read in the user's timezone
+	loc, err := userLocation(userID)
+	if err != nil {
+		return err
+	}
+
+	var logs []importedLog
+	var rowErrors []dto.ImportRowError
+	for i, record := range records {
+		// The header is line 1
+		row, problems := helper.ParseImportRow(i+2, record, columns, loc)
+		if len(problems) > 0 {
+			rowErrors = append(rowErrors, dto.ImportRowError{Row: row.Line, Errors: problems})
+			continue
+		}
+
+		logs = append(logs, importedLog{
+			Line:     row.Line,
+			Workout:  row.Workout,
+			Exercise: row.Exercise,
+			Log: model.ExerciseLog{
+				SetCount:        row.SetCount,
+				RepCount:        row.RepCount,
+				Weight:          helper.ToKg(row.Weight, unit),
+				DurationSeconds: row.DurationSeconds,
+				DistanceMeters:  row.DistanceMeters,
+				Notes:           row.Notes,
+				Tempo:           row.Tempo,
+				RestSeconds:     row.RestSeconds,
+				PerceivedEffort: row.PerceivedEffort,
+				CreatedAt:       row.Date,
+			},
+		})
+	}
+
+	if len(records) == 0 {
+		return echo.NewHTTPError(http.StatusBadRequest, dto.ErrorResponse{
+			Message: "Invalid CSV: no rows to import",
+		})
+	}
+
+	return runLogImport(c, userID, logImport{
+		Source:    importSourceCSV,
+		Logs:      logs,
+		RowErrors: rowErrors,
+		RowsTotal: len(records),
+		DryRun:    dryRun,
+	})
+}
+
+// importAppExport imports the CSV export of a tracking app. Each set is one row; the rows of
+// an exercise in a workout become one log with its sets, timestamped at the workout start.
+// Logs already recorded for the same exercise at the same time are skipped.
+func importAppExport(c echo.Context, app string) error {
+	userID, err := helper.ExtractUserID(c)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusUnauthorized, dto.ErrorResponse{
+			Message: "Failed to extract user information",
+			Details: err.Error(),
+		})
+	}
+
+	dryRun, err := parseDryRun(c)
+	if err != nil {
+		return err
+	}
+
+	header, records, err := readImportFile(c)
+	if err != nil {
+		return err
+	}
+
+	var parse helper.AppRowParser
+	switch app {
+	case helper.AppStrong:
+		// Strong does not export units, the export is read in the requested or preferred unit
+		var unit string
+		if unit, err = requestWeightUnit(c.FormValue("weight_unit"), userID); err != nil {
+			return err
+		}
+		parse, err = helper.NewStrongParser(header, unit)
+	case helper.AppHevy:
+		parse, err = helper.NewHevyParser(header)
+	}
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, dto.ErrorResponse{
+			Message: "Invalid CSV",
+			Details: err.Error(),
+		})
+	}
+
+	// Dates without a zone are read in the user's timezone
+	loc, err := userLocation(userID)
+	if err != nil {
+		return err
+	}
+
+	var logs []importedLog
+	var rowErrors []dto.ImportRowError
+	rowsTotal := 0
+	index := map[string]int{}
+	for i, record := range records {
+		// The header is line 1
+		set, problems := parse(i+2, record, loc)
+		if set.Skip {
+			continue
+		}
+		rowsTotal++
+		if len(problems) > 0 {
+			rowErrors = append(rowErrors, dto.ImportRowError{Row: set.Line, Errors: problems})
+			continue
+		}
+
+		key := strconv.FormatInt(set.Start.Unix(), 10) + "\x00" + importKey(set.Workout, set.Exercise)
+		n, ok := index[key]
+		if !ok {
+			n = len(logs)
+			index[key] = n
+			logs = append(logs, importedLog{
+				Line:     set.Line,
+				Workout:  set.Workout,
+				Exercise: set.Exercise,
+				Log:      model.ExerciseLog{CreatedAt: set.Start},
+			})
+		}
+
+		l := &logs[n]
+		l.Rows++
+		l.AppSets = append(l.AppSets, set)
+		if set.Notes != "" && !strings.Contains(l.Log.Notes, set.Notes) {
+			if l.Log.Notes != "" {
+				l.Log.Notes += "; "
+			}
+			l.Log.Notes += set.Notes
+		}
+	}
+
+	if rowsTotal == 0 {
+		return echo.NewHTTPError(http.StatusBadRequest, dto.ErrorResponse{
+			Message: "Invalid CSV: no sets to import",
+		})
+	}
+
+	return runLogImport(c, userID, logImport{
+		Source:    app,
+		Logs:      logs,
+		RowErrors: rowErrors,
+		RowsTotal: rowsTotal,
+		DryRun:    dryRun,
+		Dedupe:    true,
+	})
+}
+
+// ImportStrong godoc
+// @Summary      Import a Strong export
+// @Description  Imports the CSV exported by the Strong app. The sets of an exercise in a workout become one log with its sets; missing workouts and exercises are created and logs already recorded for the same exercise and time are skipped. Nothing is imported when a row is invalid.
+// @Tags         import
+// @Accept       multipart/form-data
+// @Produce      json
+// @Param        file         formData  file    true   "Strong CSV export"
+// @Param        weight_unit  formData  string  false  "Unit the export was made in, kg or lb, defaults to the preferred unit; distances are read in km, or miles for lb"
+// @Param        dry_run      formData  bool    false  "Validate and report without importing"
+// @Success      200  {object}  dto.SuccessResponse{data=dto.ImportLogsResponse}
+// @Success      201  {object}  dto.SuccessResponse{data=dto.ImportLogsResponse}
+// @Failure      400  {object}  dto.ErrorResponse
+// @Failure      401  {object}  dto.ErrorResponse
+// @Failure      413  {object}  dto.ErrorResponse
+// @Failure      422  {object}  dto.ErrorResponse{details=dto.ImportLogsResponse}
+// @Failure      500  {object}  dto.ErrorResponse
+// @Router       /api/import/strong [post]
+// @Security     BearerAuth
+func ImportStrong(c echo.Context) error {
+	return importAppExport(c, helper.AppStrong)
+}
+
+// ImportHevy godoc
+// @Summary      Import a Hevy export
+// @Description  Imports the CSV exported by the Hevy app, units are taken from the column names. The sets of an exercise in a workout become one log with its sets; missing workouts and exercises are created and logs already recorded for the same exercise and time are skipped. Nothing is imported when a row is invalid.
+// @Tags         import
+// @Accept       multipart/form-data
+// @Produce      json
+// @Param        file     formData  file  true   "Hevy CSV export"
+// @Param        dry_run  formData  bool  false  "Validate and report without importing"
+// @Success      200  {object}  dto.SuccessResponse{data=dto.ImportLogsResponse}
+// @Success      201  {object}  dto.SuccessResponse{data=dto.ImportLogsResponse}
+// @Failure      400  {object}  dto.ErrorResponse
+// @Failure      401  {object}  dto.ErrorResponse
+// @Failure      413  {object}  dto.ErrorResponse
+// @Failure      422  {object}  dto.ErrorResponse{details=dto.ImportLogsResponse}
+// @Failure      500  {object}  dto.ErrorResponse
+// @Router       /api/import/hevy [post]
+// @Security     BearerAuth
+func ImportHevy(c echo.Context) error {
+	return importAppExport(c, helper.AppHevy)
+}
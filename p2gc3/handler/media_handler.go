@@ -0,0 +1,414 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"p2gc3/config"
+	"p2gc3/dto"
+	helper "p2gc3/helpers"
+	"p2gc3/middleware"
+	"p2gc3/model"
+	"p2gc3/storage"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"gorm.io/gorm"
+)
+
+const (
+	maxImageUploadBytes = 5 << 20  // 5 MB
+	maxVideoUploadBytes = 50 << 20 // 50 MB
+	thumbnailMaxSide    = 256
+	mediaURLTTL         = 15 * time.Minute
+)
+
+// allowedMediaTypes maps sniffed content types to media kinds and file extensions
+var allowedMediaTypes = map[string]struct {
+	Kind string
+	Ext  string
+}{
+	"image/jpeg": {model.MediaKindImage, ".jpg"},
+	"image/png":  {model.MediaKindImage, ".png"},
+	"image/gif":  {model.MediaKindImage, ".gif"},
+	"video/mp4":  {model.MediaKindVideo, ".mp4"},
+	"video/webm": {model.MediaKindVideo, ".webm"},
+}
+
+func mediaPath(id uint) string {
+	return fmt.Sprintf("/media/%d", id)
+}
+
+func mediaThumbnailPath(id uint) string {
+	return fmt.Sprintf("/media/%d/thumbnail", id)
+}
+
+func toExerciseMediaResponse(m model.ExerciseMedia) dto.ExerciseMediaResponse {
+	response := dto.ExerciseMediaResponse{
+		ID:           m.ID,
+		ExerciseID:   m.ExerciseID,
+		Kind:         m.Kind,
+		ContentType:  m.ContentType,
+		SizeBytes:    m.SizeBytes,
+		OriginalName: m.OriginalName,
+		URL:          helper.SignPath(mediaPath(m.ID), mediaURLTTL),
+		CreatedAt:    m.CreatedAt,
+	}
+	if m.ThumbnailKey != "" {
+		response.ThumbnailURL = helper.SignPath(mediaThumbnailPath(m.ID), mediaURLTTL)
+	}
+	return response
+}
+
+// removeMediaBlobs deletes the stored files of media records, failures are only logged
+// since the records are already gone
+func removeMediaBlobs(ctx context.Context, media []model.ExerciseMedia) {
+	for _, m := range media {
+		for _, key := range []string{m.StorageKey, m.ThumbnailKey} {
+			if key == "" {
+				continue
+			}
+			if err := config.Storage.Delete(ctx, key); err != nil {
+				middleware.MakeLogEntry(nil).Warn("failed to delete media blob " + key + ": " + err.Error())
+			}
+		}
+	}
+}
+
+// UploadExerciseMedia godoc
+// @Summary      Upload exercise media
+// @Description  Attaches a demo image (jpeg, png, gif up to 5 MB) or video (mp4, webm up to 50 MB) to an exercise
+// @Tags         exercises
+// @Accept       multipart/form-data
+// @Produce      json
+// @Param        id    path      int   true  "Exercise ID"
+// @Param        file  formData  file  true  "Image or video file"
+// @Success      201  {object}  dto.SuccessResponse{data=dto.ExerciseMediaResponse}
+// @Failure      400  {object}  dto.ErrorResponse
+// @Failure      401  {object}  dto.ErrorResponse
+// @Failure      403  {object}  dto.ErrorResponse
+// @Failure      404  {object}  dto.ErrorResponse
+// @Failure      413  {object}  dto.ErrorResponse
+// @Failure      415  {object}  dto.ErrorResponse
+// @Failure      500  {object}  dto.ErrorResponse
+// @Router       /api/exercises/{id}/media [post]
+// @Security     BearerAuth
+func UploadExerciseMedia(c echo.Context) error {
+	userID, err := helper.ExtractUserID(c)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusUnauthorized, dto.ErrorResponse{
+			Message: "Failed to extract user information",
+			Details: err.Error(),
+		})
+	}
+
+	exercise, err := findOwnedExercise(c, userID)
+	if err != nil {
+		return err
+	}
+
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, dto.ErrorResponse{
+			Message: "Invalid input: multipart field file is required",
+			Details: err.Error(),
+		})
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, dto.ErrorResponse{
+			Message: "Invalid input: failed to read uploaded file",
+			Details: err.Error(),
+		})
+	}
+	defer file.Close()
+
+	// Trust the file content rather than the client supplied Content-Type
+	head := make([]byte, 512)
+	n, err := io.ReadFull(file, head)
+	if err != nil && !errors.Is(err, io.ErrUnexpectedEOF) && !errors.Is(err, io.EOF) {
+		return echo.NewHTTPError(http.StatusBadRequest, dto.ErrorResponse{
+			Message: "Invalid input: failed to read uploaded file",
+			Details: err.Error(),
+		})
+	}
+	head = head[:n]
+
+	contentType := http.DetectContentType(head)
+	mediaType, ok := allowedMediaTypes[contentType]
+	if !ok {
+		return echo.NewHTTPError(http.StatusUnsupportedMediaType, dto.ErrorResponse{
+			Message: "Unsupported media type",
+			Details: contentType,
+		})
+	}
+
+	limit := int64(maxImageUploadBytes)
+	if mediaType.Kind == model.MediaKindVideo {
+		limit = maxVideoUploadBytes
+	}
+	if fileHeader.Size > limit {
+		return echo.NewHTTPError(http.StatusRequestEntityTooLarge, dto.ErrorResponse{
+			Message: "File is too large",
+			Details: map[string]int64{"max_bytes": limit, "size_bytes": fileHeader.Size},
+		})
+	}
+
+	suffix := make([]byte, 8)
+	if _, err := rand.Read(suffix); err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, dto.ErrorResponse{
+			Message: "Failed to generate storage key",
+			Details: err.Error(),
+		})
+	}
+	baseKey := fmt.Sprintf("exercises/%d/%d-%s", exercise.ID, time.Now().UnixNano(), hex.EncodeToString(suffix))
+
+	media := model.ExerciseMedia{
+		ExerciseID:   exercise.ID,
+		UserID:       userID,
+		Kind:         mediaType.Kind,
+		ContentType:  contentType,
+		SizeBytes:    fileHeader.Size,
+		OriginalName: filepath.Base(fileHeader.Filename),
+		StorageKey:   baseKey + mediaType.Ext,
+		CreatedAt:    time.Now(),
+	}
+
+	ctx := c.Request().Context()
+	if err := config.Storage.Put(ctx, media.StorageKey, io.MultiReader(bytes.NewReader(head), file), contentType); err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, dto.ErrorResponse{
+			Message: "Failed to store file",
+			Details: err.Error(),
+		})
+	}
+
+	if media.Kind == model.MediaKindImage {
+		if _, err := file.Seek(0, io.SeekStart); err != nil {
+			removeMediaBlobs(ctx, []model.ExerciseMedia{media})
+			return echo.NewHTTPError(http.StatusInternalServerError, dto.ErrorResponse{
+				Message: "Failed to read uploaded file",
+				Details: err.Error(),
+			})
+		}
+
+		thumb, err := helper.GenerateThumbnail(file, thumbnailMaxSide)
+		if err != nil {
+			removeMediaBlobs(ctx, []model.ExerciseMedia{media})
+			return echo.NewHTTPError(http.StatusBadRequest, dto.ErrorResponse{
+				Message: "Invalid input: image could not be decoded",
+				Details: err.Error(),
+			})
+		}
+
+		media.ThumbnailKey = baseKey + "-thumb.jpg"
+		if err := config.Storage.Put(ctx, media.ThumbnailKey, bytes.NewReader(thumb), "image/jpeg"); err != nil {
+			removeMediaBlobs(ctx, []model.ExerciseMedia{media})
+			return echo.NewHTTPError(http.StatusInternalServerError, dto.ErrorResponse{
+				Message: "Failed to store thumbnail",
+				Details: err.Error(),
+			})
+		}
+	}
+
+	if err := config.DB.Create(&media).Error; err != nil {
+		removeMediaBlobs(ctx, []model.ExerciseMedia{media})
+		return echo.NewHTTPError(http.StatusInternalServerError, dto.ErrorResponse{
+			Message: "Failed to save media",
+			Details: err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusCreated, dto.SuccessResponse{
+		Message: "Media uploaded successfully",
+		Data:    toExerciseMediaResponse(media),
+	})
+}
+
+// GetExerciseMedia godoc
+// @Summary      List exercise media
+// @Description  Lists media of an exercise with signed, expiring download URLs
+// @Tags         exercises
+// @Produce      json
+// @Param        id  path  int  true  "Exercise ID"
+// @Success      200  {object}  dto.SuccessResponse{data=[]dto.ExerciseMediaResponse}
+// @Failure      400  {object}  dto.ErrorResponse
+// @Failure      401  {object}  dto.ErrorResponse
+// @Failure      403  {object}  dto.ErrorResponse
+// @Failure      404  {object}  dto.ErrorResponse
+// @Failure      500  {object}  dto.ErrorResponse
+// @Router       /api/exercises/{id}/media [get]
+// @Security     BearerAuth
+func GetExerciseMedia(c echo.Context) error {
+	userID, err := helper.ExtractUserID(c)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusUnauthorized, dto.ErrorResponse{
+			Message: "Failed to extract user information",
+			Details: err.Error(),
+		})
+	}
+
+	exercise, err := findOwnedExercise(c, userID)
+	if err != nil {
+		return err
+	}
+
+	var media []model.ExerciseMedia
+	if err := config.DB.Where("exercise_id = ?", exercise.ID).Order("created_at").Find(&media).Error; err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, dto.ErrorResponse{
+			Message: "Failed to retrieve media",
+			Details: err.Error(),
+		})
+	}
+
+	response := []dto.ExerciseMediaResponse{}
+	for _, m := range media {
+		response = append(response, toExerciseMediaResponse(m))
+	}
+
+	return c.JSON(http.StatusOK, dto.SuccessResponse{
+		Message: "Success Retrieving Media",
+		Data:    response,
+	})
+}
+
+// DeleteExerciseMedia godoc
+// @Summary      Delete exercise media
+// @Description  Removes a media attachment and its stored files
+// @Tags         exercises
+// @Produce      json
+// @Param        id       path  int  true  "Exercise ID"
+// @Param        mediaId  path  int  true  "Media ID"
+// @Success      200  {object}  dto.SuccessResponse
+// @Failure      400  {object}  dto.ErrorResponse
+// @Failure      401  {object}  dto.ErrorResponse
+// @Failure      403  {object}  dto.ErrorResponse
+// @Failure      404  {object}  dto.ErrorResponse
+// @Failure      500  {object}  dto.ErrorResponse
+// @Router       /api/exercises/{id}/media/{mediaId} [delete]
+// @Security     BearerAuth
+func DeleteExerciseMedia(c echo.Context) error {
+	userID, err := helper.ExtractUserID(c)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusUnauthorized, dto.ErrorResponse{
+			Message: "Unauthorized",
+			Details: err.Error(),
+		})
+	}
+
+	exercise, err := findOwnedExercise(c, userID)
+	if err != nil {
+		return err
+	}
+
+	mediaID, err := strconv.Atoi(c.Param("mediaId"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, dto.ErrorResponse{
+			Message: "Invalid media ID",
+			Details: err.Error(),
+		})
+	}
+
+	var media model.ExerciseMedia
+	err = config.DB.Where("exercise_id = ?", exercise.ID).First(&media, mediaID).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return echo.NewHTTPError(http.StatusNotFound, dto.ErrorResponse{
+			Message: "Media not found",
+		})
+	} else if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, dto.ErrorResponse{
+			Message: "Failed to retrieve media",
+			Details: err.Error(),
+		})
+	}
+
+	if err := config.DB.Delete(&media).Error; err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, dto.ErrorResponse{
+			Message: "Failed to delete media",
+			Details: err.Error(),
+		})
+	}
+
+	removeMediaBlobs(c.Request().Context(), []model.ExerciseMedia{media})
+
+	return c.JSON(http.StatusOK, dto.SuccessResponse{
+		Message: "Media deleted successfully",
+	})
+}
+
+// DownloadMedia godoc
+// @Summary      Download media
+// @Description  Streams a media file or its thumbnail; access is granted by the signed URL, no token needed
+// @Tags         media
+// @Produce      octet-stream
+// @Param        id       path   int     true  "Media ID"
+// @Param        expires  query  int     true  "Expiry (unix seconds)"
+// @Param        sig      query  string  true  "Signature"
+// @Success      200
+// @Failure      403  {object}  dto.ErrorResponse
+// @Failure      404  {object}  dto.ErrorResponse
+// @Failure      500  {object}  dto.ErrorResponse
+// @Router       /media/{id} [get]
+// @Router       /media/{id}/thumbnail [get]
+func DownloadMedia(c echo.Context) error {
+	if err := helper.VerifyPathSignature(c.Request().URL.Path, c.QueryParam("expires"), c.QueryParam("sig")); err != nil {
+		return echo.NewHTTPError(http.StatusForbidden, dto.ErrorResponse{
+			Message: "Access to media denied",
+			Details: err.Error(),
+		})
+	}
+
+	mediaID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, dto.ErrorResponse{
+			Message: "Invalid media ID",
+			Details: err.Error(),
+		})
+	}
+
+	var media model.ExerciseMedia
+	err = config.DB.First(&media, mediaID).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return echo.NewHTTPError(http.StatusNotFound, dto.ErrorResponse{
+			Message: "Media not found",
+		})
+	} else if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, dto.ErrorResponse{
+			Message: "Failed to retrieve media",
+			Details: err.Error(),
+		})
+	}
+
+	key, contentType := media.StorageKey, media.ContentType
+	if c.Request().URL.Path == mediaThumbnailPath(media.ID) {
+		if media.ThumbnailKey == "" {
+			return echo.NewHTTPError(http.StatusNotFound, dto.ErrorResponse{
+				Message: "Media has no thumbnail",
+			})
+		}
+		key, contentType = media.ThumbnailKey, "image/jpeg"
+	}
+
+	blob, err := config.Storage.Get(c.Request().Context(), key)
+	if errors.Is(err, storage.ErrNotFound) {
+		return echo.NewHTTPError(http.StatusNotFound, dto.ErrorResponse{
+			Message: "Media file not found",
+		})
+	} else if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, dto.ErrorResponse{
+			Message: "Failed to read media file",
+			Details: err.Error(),
+		})
+	}
+	defer blob.Close()
+
+	c.Response().Header().Set("Cache-Control", "private, max-age=300")
+	return c.Stream(http.StatusOK, contentType, blob)
+}
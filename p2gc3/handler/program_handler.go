@@ -0,0 +1,519 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"p2gc3/config"
+	"p2gc3/dto"
+	helper "p2gc3/helpers"
+	"p2gc3/model"
+	"strconv"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/labstack/echo/v4"
+	"gorm.io/gorm"
+)
+
+const dateLayout = "2006-01-02"
+
+// truncateToDay drops the clock part of t, keeping its location
+func truncateToDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+}
+
+// CreateProgram godoc
+// @Summary      Create a training program
+// @Description  Creates a multi-week program whose days reference the user's workouts
+// @Tags         programs
+// @Accept       json
+// @Produce      json
+// @Param        program  body  dto.ProgramCreateRequest  true  "Program payload"
+// @Success      201  {object}  dto.SuccessResponse{data=dto.ProgramResponse}
+// @Failure      400  {object}  dto.ErrorResponse
+// @Failure      401  {object}  dto.ErrorResponse
+// @Failure      403  {object}  dto.ErrorResponse
+// @Failure      500  {object}  dto.ErrorResponse
+// @Router       /api/programs [post]
+// @Security     BearerAuth
+func CreateProgram(c echo.Context) error {
+	userID, err := helper.ExtractUserID(c)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusUnauthorized, dto.ErrorResponse{
+			Message: "Failed to extract user information",
+			Details: err.Error(),
+		})
+	}
+
+	var req dto.ProgramCreateRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, dto.ErrorResponse{
+			Message: "Invalid input",
+			Details: err.Error(),
+		})
+	}
+
+	if req.Name == "" || req.Description == "" || len(req.Weeks) == 0 {
+		return echo.NewHTTPError(http.StatusBadRequest, dto.ErrorResponse{
+			Message: "Invalid input: program name, description and at least one week are required",
+		})
+	}
+
+	program := model.Program{
+		UserID:      userID,
+		Name:        req.Name,
+		Description: req.Description,
+	}
+
+	workoutIDs := map[uint]bool{}
+	seenWeeks := map[int]bool{}
+	for _, w := range req.Weeks {
+		if w.WeekNumber < 1 || seenWeeks[w.WeekNumber] {
+			return echo.NewHTTPError(http.StatusBadRequest, dto.ErrorResponse{
+				Message: "Invalid input: week numbers must be positive and unique",
+				Details: w.WeekNumber,
+			})
+		}
+		seenWeeks[w.WeekNumber] = true
+
+		if w.IntensityModifier < 0 || w.VolumeModifier < 0 {
+			return echo.NewHTTPError(http.StatusBadRequest, dto.ErrorResponse{
+				Message: "Invalid input: modifiers cannot be negative",
+				Details: w.WeekNumber,
+			})
+		}
+
+		week := model.ProgramWeek{
+			WeekNumber:        w.WeekNumber,
+			IntensityModifier: w.IntensityModifier,
+			VolumeModifier:    w.VolumeModifier,
+		}
+		if week.IntensityModifier == 0 {
+			week.IntensityModifier = 1
+		}
+		if week.VolumeModifier == 0 {
+			week.VolumeModifier = 1
+		}
+
+		seenDays := map[int]bool{}
+		for _, d := range w.Days {
+			if d.DayNumber < 1 || d.DayNumber > 7 || seenDays[d.DayNumber] {
+				return echo.NewHTTPError(http.StatusBadRequest, dto.ErrorResponse{
+					Message: "Invalid input: day numbers must be unique and between 1 and 7",
+					Details: map[string]int{"week_number": w.WeekNumber, "day_number": d.DayNumber},
+				})
+			}
+			seenDays[d.DayNumber] = true
+			workoutIDs[d.WorkoutID] = true
+
+			week.Days = append(week.Days, model.ProgramDay{
+				DayNumber: d.DayNumber,
+				WorkoutID: d.WorkoutID,
+			})
+		}
+
+		program.Weeks = append(program.Weeks, week)
+	}
+
+	// Referenced workouts must all belong to the user
+	ids := make([]uint, 0, len(workoutIDs))
+	for id := range workoutIDs {
+		ids = append(ids, id)
+	}
+	var owned int64
+	if err := config.DB.Model(&model.Workout{}).
+		Where("id IN ? AND user_id = ?", ids, userID).
+		Count(&owned).Error; err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, dto.ErrorResponse{
+			Message: "Failed to fetch workouts",
+			Details: err.Error(),
+		})
+	}
+	if int(owned) != len(ids) {
+		return echo.NewHTTPError(http.StatusForbidden, dto.ErrorResponse{
+			Message: "Program references workouts that do not exist or are not yours",
+		})
+	}
+
+	// Weeks and days are created together with the program
+	if err := config.DB.Create(&program).Error; err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, dto.ErrorResponse{
+			Message: "Failed to create program",
+			Details: err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusCreated, dto.SuccessResponse{
+		Message: "Program successfully created",
+		Data: dto.ProgramResponse{
+			ID:          program.ID,
+			Name:        program.Name,
+			Description: program.Description,
+			WeekCount:   len(program.Weeks),
+			Weeks:       program.Weeks,
+		},
+	})
+}
+
+// GetPrograms godoc
+// @Summary      Get all programs
+// @Description  Retrieves all programs owned by the authenticated user
+// @Tags         programs
+// @Produce      json
+// @Success      200  {object}  dto.SuccessResponse{data=[]dto.ProgramResponse}
+// @Failure      401  {object}  dto.ErrorResponse
+// @Failure      500  {object}  dto.ErrorResponse
+// @Router       /api/programs [get]
+// @Security     BearerAuth
+func GetPrograms(c echo.Context) error {
+	userID, err := helper.ExtractUserID(c)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusUnauthorized, dto.ErrorResponse{
+			Message: "Failed to extract user information",
+			Details: err.Error(),
+		})
+	}
+
+	var programs []model.Program
+	if err := config.DB.Preload("Weeks").
+		Where("user_id = ?", userID).
+		Find(&programs).Error; err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, dto.ErrorResponse{
+			Message: "Failed to retrieve programs",
+			Details: err.Error(),
+		})
+	}
+
+	response := []dto.ProgramResponse{}
+	for _, p := range programs {
+		response = append(response, dto.ProgramResponse{
+			ID:          p.ID,
+			Name:        p.Name,
+			Description: p.Description,
+			WeekCount:   len(p.Weeks),
+		})
+	}
+
+	return c.JSON(http.StatusOK, dto.SuccessResponse{
+		Message: "Success Retrieving Programs",
+		Data:    response,
+	})
+}
+
+// GetProgramByID godoc
+// @Summary      Get program by ID
+// @Description  Retrieves a program with its weeks and days
+// @Tags         programs
+// @Produce      json
+// @Param        id  path  int  true  "Program ID"
+// @Success      200  {object}  dto.SuccessResponse{data=dto.ProgramResponse}
+// @Failure      400  {object}  dto.ErrorResponse
+// @Failure      401  {object}  dto.ErrorResponse
+// @Failure      403  {object}  dto.ErrorResponse
+// @Failure      404  {object}  dto.ErrorResponse
+// @Router       /api/programs/{id} [get]
+// @Security     BearerAuth
+func GetProgramByID(c echo.Context) error {
+	userID, err := helper.ExtractUserID(c)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusUnauthorized, dto.ErrorResponse{
+			Message: "Failed to extract user information",
+			Details: err.Error(),
+		})
+	}
+
+	programID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, dto.ErrorResponse{
+			Message: "Invalid program ID",
+			Details: err.Error(),
+		})
+	}
+
+	var program model.Program
+	err = config.DB.
+		Preload("Weeks", func(db *gorm.DB) *gorm.DB { return db.Order("week_number") }).
+		Preload("Weeks.Days", func(db *gorm.DB) *gorm.DB { return db.Order("day_number") }).
+		First(&program, programID).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return echo.NewHTTPError(http.StatusNotFound, dto.ErrorResponse{
+			Message: "Program not found",
+		})
+	} else if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, dto.ErrorResponse{
+			Message: "Failed to retrieve program",
+			Details: err.Error(),
+		})
+	}
+
+	if program.UserID != userID {
+		return echo.NewHTTPError(http.StatusForbidden, dto.ErrorResponse{
+			Message: "You are not authorized to view this program",
+		})
+	}
+
+	return c.JSON(http.StatusOK, dto.SuccessResponse{
+		Message: "Success Retrieving Program",
+		Data: dto.ProgramResponse{
+			ID:          program.ID,
+			Name:        program.Name,
+			Description: program.Description,
+			WeekCount:   len(program.Weeks),
+			Weeks:       program.Weeks,
+		},
+	})
+}
+
+// EnrollProgram godoc
+// @Summary      Enroll into a program
+// @Description  Enrolls the authenticated user into a program from a start date, replacing any active enrollment
+// @Tags         programs
+// @Accept       json
+// @Produce      json
+// @Param        id          path  int                       true  "Program ID"
+// @Param        enrollment  body  dto.ProgramEnrollRequest  false "Enrollment payload"
+// @Success      201  {object}  dto.SuccessResponse{data=dto.ProgramEnrollmentResponse}
+// @Failure      400  {object}  dto.ErrorResponse
+// @Failure      401  {object}  dto.ErrorResponse
+// @Failure      403  {object}  dto.ErrorResponse
+// @Failure      404  {object}  dto.ErrorResponse
+// @Failure      409  {object}  dto.ErrorResponse
+// @Failure      500  {object}  dto.ErrorResponse
+// @Router       /api/programs/{id}/enroll [post]
+// @Security     BearerAuth
+func EnrollProgram(c echo.Context) error {
+	userID, err := helper.ExtractUserID(c)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusUnauthorized, dto.ErrorResponse{
+			Message: "Failed to extract user information",
+			Details: err.Error(),
+		})
+	}
+
+	programID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, dto.ErrorResponse{
+			Message: "Invalid program ID",
+			Details: err.Error(),
+		})
+	}
+
+	var req dto.ProgramEnrollRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, dto.ErrorResponse{
+			Message: "Invalid input",
+			Details: err.Error(),
+		})
+	}
+
+	// The program starts at midnight in the user's timezone
+	loc, err := userLocation(userID)
+	if err != nil {
+		return err
+	}
+
+	startDate := truncateToDay(time.Now().In(loc))
+	if req.StartDate != "" {
+		startDate, err = time.ParseInLocation(dateLayout, req.StartDate, loc)
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, dto.ErrorResponse{
+				Message: "Invalid start_date, expected YYYY-MM-DD",
+				Details: err.Error(),
+			})
+		}
+	}
+
+	var program model.Program
+	err = config.DB.First(&program, programID).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return echo.NewHTTPError(http.StatusNotFound, dto.ErrorResponse{
+			Message: "Program not found",
+		})
+	} else if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, dto.ErrorResponse{
+			Message: "Failed to retrieve program",
+			Details: err.Error(),
+		})
+	}
+
+	if program.UserID != userID {
+		return echo.NewHTTPError(http.StatusForbidden, dto.ErrorResponse{
+			Message: "You are not authorized to enroll into this program",
+		})
+	}
+
+	enrollment := model.ProgramEnrollment{
+		UserID:    userID,
+		ProgramID: program.ID,
+		StartDate: startDate,
+		Active:    true,
+	}
+
+	err = config.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&model.ProgramEnrollment{}).
+			Where("user_id = ? AND active = ?", userID, true).
+			Update("active", false).Error; err != nil {
+			return echo.NewHTTPError(http.StatusInternalServerError, dto.ErrorResponse{
+				Message: "Failed to close previous enrollment",
+				Details: err.Error(),
+			})
+		}
+
+		err := tx.Create(&enrollment).Error
+		// The unique index on active enrollments rejects the loser of two concurrent enrollments
+		// with a unique_violation
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+			return echo.NewHTTPError(http.StatusConflict, dto.ErrorResponse{
+				Message: "Another enrollment was started at the same time, try again",
+			})
+		} else if err != nil {
+			return echo.NewHTTPError(http.StatusInternalServerError, dto.ErrorResponse{
+				Message: "Failed to enroll into program",
+				Details: err.Error(),
+			})
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusCreated, dto.SuccessResponse{
+		Message: "Enrolled into program",
+		Data: dto.ProgramEnrollmentResponse{
+			ID:        enrollment.ID,
+			ProgramID: enrollment.ProgramID,
+			StartDate: enrollment.StartDate,
+			Active:    enrollment.Active,
+		},
+	})
+}
+
+// GetTodayWorkout godoc
+// @Summary      Get today's workout
+// @Description  Derives today's workout from the active program enrollment, with the week's modifiers applied
+// @Tags         programs
+// @Produce      json
+// @Success      200  {object}  dto.SuccessResponse{data=dto.TodayWorkoutResponse}
+// @Failure      401  {object}  dto.ErrorResponse
+// @Failure      404  {object}  dto.ErrorResponse
+// @Failure      500  {object}  dto.ErrorResponse
+// @Router       /api/programs/today [get]
+// @Security     BearerAuth
+func GetTodayWorkout(c echo.Context) error {
+	userID, err := helper.ExtractUserID(c)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusUnauthorized, dto.ErrorResponse{
+			Message: "Failed to extract user information",
+			Details: err.Error(),
+		})
+	}
+
+	var enrollment model.ProgramEnrollment
+	err = config.DB.
+		Preload("Program.Weeks.Days").
+		Where("user_id = ? AND active = ?", userID, true).
+		First(&enrollment).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return echo.NewHTTPError(http.StatusNotFound, dto.ErrorResponse{
+			Message: "No active program enrollment",
+		})
+	} else if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, dto.ErrorResponse{
+			Message: "Failed to retrieve enrollment",
+			Details: err.Error(),
+		})
+	}
+
+	loc, err := userLocation(userID)
+	if err != nil {
+		return err
+	}
+
+	today := truncateToDay(time.Now().In(loc))
+	start := truncateToDay(enrollment.StartDate.In(loc))
+	// Counted on calendar days, days around daylight saving changes are not 24 hours long
+	elapsedDays := helper.DayNumber(today) - helper.DayNumber(start)
+
+	if elapsedDays < 0 {
+		return echo.NewHTTPError(http.StatusNotFound, dto.ErrorResponse{
+			Message: "Program has not started yet",
+			Details: start.Format(dateLayout),
+		})
+	}
+
+	response := dto.TodayWorkoutResponse{
+		ProgramID:   enrollment.ProgramID,
+		ProgramName: enrollment.Program.Name,
+		Date:        today.Format(dateLayout),
+		WeekNumber:  elapsedDays/7 + 1,
+		DayNumber:   elapsedDays%7 + 1,
+		RestDay:     true,
+	}
+
+	lastWeek := 0
+	var week *model.ProgramWeek
+	for i := range enrollment.Program.Weeks {
+		w := &enrollment.Program.Weeks[i]
+		if w.WeekNumber > lastWeek {
+			lastWeek = w.WeekNumber
+		}
+		if w.WeekNumber == response.WeekNumber {
+			week = w
+		}
+	}
+
+	if response.WeekNumber > lastWeek {
+		response.Completed = true
+		return c.JSON(http.StatusOK, dto.SuccessResponse{
+			Message: "Program completed",
+			Data:    response,
+		})
+	}
+
+	if week == nil {
+		return c.JSON(http.StatusOK, dto.SuccessResponse{
+			Message: "Rest day",
+			Data:    response,
+		})
+	}
+
+	response.IntensityModifier = week.IntensityModifier
+	response.VolumeModifier = week.VolumeModifier
+
+	for _, d := range week.Days {
+		if d.DayNumber != response.DayNumber {
+			continue
+		}
+
+		var w model.Workout
+		if err := config.DB.Preload("Exercises").Preload("Groups").First(&w, d.WorkoutID).Error; err != nil {
+			return echo.NewHTTPError(http.StatusInternalServerError, dto.ErrorResponse{
+				Message: "Failed to retrieve scheduled workout",
+				Details: err.Error(),
+			})
+		}
+
+		response.RestDay = false
+		response.Workout = &dto.WorkoutByIDResponse{
+			ID:          w.ID,
+			Name:        w.Name,
+			Description: w.Description,
+			UserID:      w.UserID,
+			Exercises:   w.Exercises,
+			Groups:      buildGroupResponses(w.Groups, w.Exercises),
+		}
+		break
+	}
+
+	message := "Today's workout retrieved"
+	if response.RestDay {
+		message = "Rest day"
+	}
+
+	return c.JSON(http.StatusOK, dto.SuccessResponse{
+		Message: message,
+		Data:    response,
+	})
+}
@@ -0,0 +1,183 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"p2gc3/config"
+	"p2gc3/dto"
+	helper "p2gc3/helpers"
+	"p2gc3/model"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+	"gorm.io/gorm"
+)
+
+// progressionHistorySize is how many recent logs feed the progression strategies
+const progressionHistorySize = 5
+
+// GetNextTarget godoc
+// @Summary      Suggest the next target for an exercise
+// @Description  Suggests sets, reps and weight for the next session based on recent logs of the catalog entry the exercise is linked to and a progression strategy. Only strength and bodyweight exercises have targets, the weight of bodyweight exercises is the added weight.
+// @Tags         exercises
+// @Produce      json
+// @Param        id          path   int     true   "Exercise ID"
+// @Param        strategy    query  string  false  "linear (default), double or rpe (uses the RPE logged on the last session's sets)"
+// @Param        increment   query  number  false  "Weight step in the preferred unit, greater than 0, defaults to 2 kg or 5 lb"
+// @Param        rep_min     query  int     false  "Double progression lower bound, defaults to 8"
+// @Param        rep_max     query  int     false  "Double progression upper bound, defaults to 12"
+// @Param        target_rpe  query  number  false  "RPE strategy target, defaults to 8"
+// @Success      200  {object}  dto.SuccessResponse{data=dto.NextTargetResponse}
+// @Failure      400  {object}  dto.ErrorResponse
+// @Failure      401  {object}  dto.ErrorResponse
+// @Failure      403  {object}  dto.ErrorResponse
+// @Failure      404  {object}  dto.ErrorResponse
+// @Failure      500  {object}  dto.ErrorResponse
+// @Router       /api/exercises/{id}/next-target [get]
+// @Security     BearerAuth
+func GetNextTarget(c echo.Context) error {
+	userID, err := helper.ExtractUserID(c)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusUnauthorized, dto.ErrorResponse{
+			Message: "Failed to extract user information",
+			Details: err.Error(),
+		})
+	}
+
+	exerciseID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, dto.ErrorResponse{
+			Message: "Invalid exercise ID",
+			Details: err.Error(),
+		})
+	}
+
+	// History and targets are expressed in the preferred unit so increments match the user's plates
+	unit, err := userWeightUnit(userID)
+	if err != nil {
+		return err
+	}
+
+	increment := 2.0
+	if unit == helper.UnitLb {
+		increment = 5
+	}
+
+	cfg := helper.ProgressionConfig{
+		Strategy:  helper.StrategyLinear,
+		Increment: increment,
+		RepMin:    8,
+		RepMax:    12,
+		TargetRPE: 8,
+	}
+	if s := c.QueryParam("strategy"); s != "" {
+		cfg.Strategy = s
+	}
+	if err := echo.QueryParamsBinder(c).
+		Float64("increment", &cfg.Increment).
+		Int("rep_min", &cfg.RepMin).
+		Int("rep_max", &cfg.RepMax).
+		Float64("target_rpe", &cfg.TargetRPE).
+		BindError(); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, dto.ErrorResponse{
+			Message: "Invalid query parameters",
+			Details: err.Error(),
+		})
+	}
+
+	var exercise model.Exercise
+	err = config.DB.Preload("Workout").First(&exercise, exerciseID).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return echo.NewHTTPError(http.StatusNotFound, dto.ErrorResponse{
+			Message: "Exercise not found",
+		})
+	} else if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, dto.ErrorResponse{
+			Message: "Failed to retrieve exercise",
+			Details: err.Error(),
+		})
+	}
+
+	if exercise.Workout.UserID != userID {
+		return echo.NewHTTPError(http.StatusForbidden, dto.ErrorResponse{
+			Message: "You are not authorized to view this exercise",
+		})
+	}
+
+	// Strategies progress reps and load, the load of bodyweight exercises is the added weight
+	exerciseType, err := exerciseTypeOf(exercise)
+	if err != nil {
+		return err
+	}
+	if exerciseType != model.TypeStrength && exerciseType != model.TypeBodyweight {
+		return echo.NewHTTPError(http.StatusBadRequest, dto.ErrorResponse{
+			Message: "Invalid input: next targets are only suggested for strength and bodyweight exercises",
+			Details: map[string]string{"exercise_type": exerciseType},
+		})
+	}
+
+	// Only logs of the movement done now count, not the ones from before a swap
+	var logs []model.ExerciseLog
+	if err := withSets(config.HistoryScope(config.DB, "exercise_logs", userID, exercise.DefinitionID, exercise.ID)).
+		Order("created_at desc").
+		Limit(progressionHistorySize).
+		Find(&logs).Error; err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, dto.ErrorResponse{
+			Message: "Failed to retrieve exercise logs",
+			Details: err.Error(),
+		})
+	}
+
+	if len(logs) == 0 {
+		return echo.NewHTTPError(http.StatusNotFound, dto.ErrorResponse{
+			Message: "No logs found for this exercise, log a session first",
+		})
+	}
+
+	history := make([]helper.ProgressionSet, 0, len(logs))
+	for _, l := range logs {
+		sets := make([]helper.SetValues, 0, len(l.Sets))
+		for _, s := range l.Sets {
+			sets = append(sets, helper.SetValues{SetType: s.SetType, RPE: s.RPE})
+		}
+		// The hardest logged set tells how the session went, the overall effort when sets have no RPE
+		rpe := helper.HardestRPE(sets)
+		if rpe == nil {
+			rpe = l.PerceivedEffort
+		}
+
+		weight := l.Weight
+		if exerciseType == model.TypeBodyweight {
+			weight = l.AddedWeight
+		}
+
+		history = append(history, helper.ProgressionSet{
+			SetCount: l.SetCount,
+			RepCount: l.RepCount,
+			Weight:   helper.FromKg(weight, unit),
+			RPE:      rpe,
+		})
+	}
+
+	target, err := helper.NextTarget(history, cfg)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, dto.ErrorResponse{
+			Message: "Invalid progression settings",
+			Details: err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, dto.SuccessResponse{
+		Message: "Next target computed",
+		Data: dto.NextTargetResponse{
+			ExerciseID: exercise.ID,
+			Strategy:   cfg.Strategy,
+			SetCount:   target.SetCount,
+			RepCount:   target.RepCount,
+			Weight:     helper.RoundWeight(target.Weight),
+			WeightUnit: unit,
+			Reasoning:  target.Reasoning,
+			BasedOn:    len(logs),
+		},
+	})
+}
@@ -0,0 +1,270 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"p2gc3/config"
+	"p2gc3/dto"
+	helper "p2gc3/helpers"
+	"p2gc3/model"
+	"sort"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+	"gorm.io/gorm"
+)
+
+// detectPersonalRecords stores the records a newly created log sets and returns them. The latest
+// log is compared against the current records; a backdated one can take records from later logs,
+// so the history of the exercise is replayed instead.
+func detectPersonalRecords(tx *gorm.DB, exerciseType string, log model.ExerciseLog) ([]model.PersonalRecord, error) {
+	var later int64
+	if err := config.HistoryScope(tx.Model(&model.ExerciseLog{}), "exercise_logs", log.UserID, log.DefinitionID, log.ExerciseID).
+		Where("exercise_logs.created_at > ?", log.CreatedAt).
+		Count(&later).Error; err != nil {
+		return nil, err
+	}
+	if later > 0 {
+		if err := config.RecomputeLogRecords(tx, []model.ExerciseLog{log}); err != nil {
+			return nil, err
+		}
+		var records []model.PersonalRecord
+		err := tx.Where("exercise_log_id = ?", log.ID).Order("id").Find(&records).Error
+		return records, err
+	}
+
+	candidates := helper.RecordCandidates(helper.RecordSets(exerciseType, log))
+	if len(candidates) == 0 {
+		return nil, nil
+	}
+
+	var existing []model.PersonalRecord
+	if err := config.HistoryScope(tx, "personal_records", log.UserID, log.DefinitionID, log.ExerciseID).Find(&existing).Error; err != nil {
+		return nil, err
+	}
+
+	best := map[string]float64{}
+	for _, r := range existing {
+		key := helper.RecordKey(r.RecordType, r.Weight)
+		if v, ok := best[key]; !ok || r.Value > v {
+			best[key] = r.Value
+		}
+	}
+
+	records := helper.BeatenRecords(log, candidates, best)
+	if len(records) == 0 {
+		return nil, nil
+	}
+	if err := tx.Create(&records).Error; err != nil {
+		return nil, err
+	}
+	return records, nil
+}
+
+// toPersonalRecordResponse converts record weights from kg to unit, most_reps values are reps
+func toPersonalRecordResponse(r model.PersonalRecord, unit string) dto.PersonalRecordResponse {
+	value, previous := r.Value, r.PreviousValue
+	if r.RecordType != model.RecordMostReps {
+		value = helper.FromKg(value, unit)
+		if previous != nil {
+			p := helper.FromKg(*previous, unit)
+			previous = &p
+		}
+	}
+
+	return dto.PersonalRecordResponse{
+		ID:            r.ID,
+		ExerciseID:    r.ExerciseID,
+		LogID:         r.ExerciseLogID,
+		RecordType:    r.RecordType,
+		Value:         value,
+		PreviousValue: previous,
+		Weight:        helper.FromKg(r.Weight, unit),
+		RepCount:      r.RepCount,
+		WeightUnit:    unit,
+		AchievedAt:    r.AchievedAt,
+	}
+}
+
+func toPersonalRecordResponses(records []model.PersonalRecord, unit string) []dto.PersonalRecordResponse {
+	response := make([]dto.PersonalRecordResponse, 0, len(records))
+	for _, r := range records {
+		response = append(response, toPersonalRecordResponse(r, unit))
+	}
+	return response
+}
+
+// currentRecords keeps the best record of every record type, and of every weight for most_reps
+func currentRecords(records []model.PersonalRecord) []model.PersonalRecord {
+	best := map[string]model.PersonalRecord{}
+	for _, r := range records {
+		key := helper.RecordKey(r.RecordType, r.Weight)
+		if b, ok := best[key]; !ok || r.Value > b.Value {
+			best[key] = r
+		}
+	}
+
+	typeOrder := map[string]int{}
+	for i, t := range model.RecordTypes {
+		typeOrder[t] = i
+	}
+
+	current := make([]model.PersonalRecord, 0, len(best))
+	for _, r := range best {
+		current = append(current, r)
+	}
+	sort.Slice(current, func(i, j int) bool {
+		if current[i].RecordType != current[j].RecordType {
+			return typeOrder[current[i].RecordType] < typeOrder[current[j].RecordType]
+		}
+		return current[i].Weight < current[j].Weight
+	})
+	return current
+}
+
+// GetPersonalRecords godoc
+// @Summary      List personal records
+// @Description  Returns the current personal records of every exercise (heaviest weight, most reps at a weight, best estimated 1RM and best volume) with the history of records beaten, most recent first. Records of exercises linked to the same catalog entry are shared across workouts.
+// @Tags         records
+// @Produce      json
+// @Param        exercise_id  query  int     false  "Only records of this exercise"
+// @Param        type         query  string  false  "heaviest_weight, most_reps, estimated_1rm or best_volume"
+// @Success      200  {object}  dto.SuccessResponse{data=[]dto.ExerciseRecordsResponse}
+// @Failure      400  {object}  dto.ErrorResponse
+// @Failure      401  {object}  dto.ErrorResponse
+// @Failure      403  {object}  dto.ErrorResponse
+// @Failure      404  {object}  dto.ErrorResponse
+// @Failure      500  {object}  dto.ErrorResponse
+// @Router       /api/records [get]
+// @Security     BearerAuth
+func GetPersonalRecords(c echo.Context) error {
+	userID, err := helper.ExtractUserID(c)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusUnauthorized, dto.ErrorResponse{
+			Message: "Failed to extract user information",
+			Details: err.Error(),
+		})
+	}
+
+	var exerciseID uint
+	if err := echo.QueryParamsBinder(c).Uint("exercise_id", &exerciseID).BindError(); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, dto.ErrorResponse{
+			Message: "Invalid query parameters",
+			Details: err.Error(),
+		})
+	}
+
+	query := config.DB.Where("user_id = ?", userID)
+
+	if exerciseID != 0 {
+		var exercise model.Exercise
+		err := config.DB.Preload("Workout").First(&exercise, exerciseID).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return echo.NewHTTPError(http.StatusNotFound, dto.ErrorResponse{
+				Message: "Exercise not found",
+			})
+		} else if err != nil {
+			return echo.NewHTTPError(http.StatusInternalServerError, dto.ErrorResponse{
+				Message: "Failed to retrieve exercise",
+				Details: err.Error(),
+			})
+		}
+		if exercise.Workout.UserID != userID {
+			return echo.NewHTTPError(http.StatusForbidden, dto.ErrorResponse{
+				Message: "You are not authorized to access this exercise",
+			})
+		}
+		query = config.HistoryScope(config.DB, "personal_records", userID, exercise.DefinitionID, exercise.ID)
+	}
+
+	if t := c.QueryParam("type"); t != "" {
+		valid := false
+		for _, recordType := range model.RecordTypes {
+			valid = valid || t == recordType
+		}
+		if !valid {
+			return echo.NewHTTPError(http.StatusBadRequest, dto.ErrorResponse{
+				Message: "Invalid type",
+				Details: model.RecordTypes,
+			})
+		}
+		query = query.Where("record_type = ?", t)
+	}
+
+	var records []model.PersonalRecord
+	if err := query.Order("achieved_at DESC, id DESC").Find(&records).Error; err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, dto.ErrorResponse{
+			Message: "Failed to fetch personal records",
+			Details: err.Error(),
+		})
+	}
+
+	unit, err := userWeightUnit(userID)
+	if err != nil {
+		return err
+	}
+
+	// Group by catalog entry, or by exercise when it is not linked to the catalog,
+	// most recently improved first
+	var keys []string
+	groups := map[string][]model.PersonalRecord{}
+	var definitionIDs, exerciseIDs []uint
+	for _, r := range records {
+		key := "e" + strconv.FormatUint(uint64(r.ExerciseID), 10)
+		if r.DefinitionID != nil {
+			key = "d" + strconv.FormatUint(uint64(*r.DefinitionID), 10)
+		}
+		if _, ok := groups[key]; !ok {
+			keys = append(keys, key)
+			if r.DefinitionID != nil {
+				definitionIDs = append(definitionIDs, *r.DefinitionID)
+			} else {
+				exerciseIDs = append(exerciseIDs, r.ExerciseID)
+			}
+		}
+		groups[key] = append(groups[key], r)
+	}
+
+	names := map[string]string{}
+	if len(definitionIDs) > 0 {
+		var definitions []model.ExerciseDefinition
+		if err := config.DB.Select("id", "name").Find(&definitions, definitionIDs).Error; err != nil {
+			return echo.NewHTTPError(http.StatusInternalServerError, dto.ErrorResponse{
+				Message: "Failed to fetch catalog exercises",
+				Details: err.Error(),
+			})
+		}
+		for _, d := range definitions {
+			names["d"+strconv.FormatUint(uint64(d.ID), 10)] = d.Name
+		}
+	}
+	if len(exerciseIDs) > 0 {
+		var exercises []model.Exercise
+		if err := config.DB.Select("id", "name").Find(&exercises, exerciseIDs).Error; err != nil {
+			return echo.NewHTTPError(http.StatusInternalServerError, dto.ErrorResponse{
+				Message: "Failed to fetch exercises",
+				Details: err.Error(),
+			})
+		}
+		for _, e := range exercises {
+			names["e"+strconv.FormatUint(uint64(e.ID), 10)] = e.Name
+		}
+	}
+
+	response := make([]dto.ExerciseRecordsResponse, 0, len(keys))
+	for _, key := range keys {
+		history := groups[key]
+		response = append(response, dto.ExerciseRecordsResponse{
+			DefinitionID: history[0].DefinitionID,
+			ExerciseID:   history[0].ExerciseID,
+			ExerciseName: names[key],
+			Current:      toPersonalRecordResponses(currentRecords(history), unit),
+			History:      toPersonalRecordResponses(history, unit),
+		})
+	}
+
+	return c.JSON(http.StatusOK, dto.SuccessResponse{
+		Message: "Personal records retrieved",
+		Data:    response,
+	})
+}
@@ -0,0 +1,129 @@
+package handler
+
+import (
+	"net/http"
+	"p2gc3/config"
+	"p2gc3/dto"
+	helper "p2gc3/helpers"
+	"strconv"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+)
+
+const (
+	searchDefaultLimit = 20
+	searchMaxLimit     = 100
+	searchHeadlineOpts = "StartSel=<mark>, StopSel=</mark>, MaxFragments=2, MaxWords=20, MinWords=5"
+)
+
+// escapeHTMLSQL wraps a SQL text expression so it is HTML-escaped. Highlights are escaped before
+// ts_headline adds its <mark> tags, the text search parser keeps the entities in one piece.
+func escapeHTMLSQL(expr string) string {
+	return `replace(replace(replace(replace(replace(` + expr +
+		`, '&', '&amp;'), '<', '&lt;'), '>', '&gt;'), '"', '&quot;'), '''', '&#39;')`
+}
+
+// searchSources are the searchable record types. Each query selects the columns of
+// dto.SearchResult for the current user, with the tsquery available as q.
+var searchSources = map[string]string{
+	"workout": `SELECT 'workout' AS type, w.id, w.id AS workout_id, w.name AS title,
+			ts_headline(@cfg::regconfig, ` + escapeHTMLSQL("w.name || ' ' || w.description") + `, q, @opts) AS highlight,
+			ts_rank(w.search_vector, q) AS rank
+		FROM workouts w, websearch_to_tsquery(@cfg::regconfig, @query) q
+		WHERE w.user_id = @user AND w.search_vector @@ q`,
+	"exercise": `SELECT 'exercise' AS type, e.id, e.workout_id, e.name AS title,
+			ts_headline(@cfg::regconfig, ` + escapeHTMLSQL("e.name || ' ' || e.description") + `, q, @opts) AS highlight,
+			ts_rank(e.search_vector, q) AS rank
+		FROM exercises e JOIN workouts w ON w.id = e.workout_id, websearch_to_tsquery(@cfg::regconfig, @query) q
+		WHERE w.user_id = @user AND e.search_vector @@ q`,
+	"log": `SELECT 'log' AS type, l.id, e.workout_id, e.name AS title,
+			ts_headline(@cfg::regconfig, ` + escapeHTMLSQL("l.notes") + `, q, @opts) AS highlight,
+			ts_rank(l.search_vector, q) AS rank
+		FROM exercise_logs l JOIN exercises e ON e.id = l.exercise_id, websearch_to_tsquery(@cfg::regconfig, @query) q
+		WHERE l.user_id = @user AND l.search_vector @@ q`,
+}
+
+// searchSourceOrder keeps the UNION stable between requests
+var searchSourceOrder = []string{"workout", "exercise", "log"}
+
+// Search godoc
+// @Summary      Full-text search
+// @Description  Searches the user's workouts, exercises and log notes, returning ranked results with highlighted matches. Highlights are HTML-escaped text with the matches wrapped in <mark>.
+// @Tags         search
+// @Produce      json
+// @Param        q      query  string  true   "Search terms, supports quotes, OR and -exclusion"
+// @Param        type   query  string  false  "Restrict to one result type (workout, exercise, log)"
+// @Param        limit  query  int     false  "Max results, defaults to 20, at most 100"
+// @Success      200  {object}  dto.SuccessResponse{data=[]dto.SearchResult}
+// @Failure      400  {object}  dto.ErrorResponse
+// @Failure      401  {object}  dto.ErrorResponse
+// @Failure      500  {object}  dto.ErrorResponse
+// @Router       /api/search [get]
+// @Security     BearerAuth
+func Search(c echo.Context) error {
+	userID, err := helper.ExtractUserID(c)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusUnauthorized, dto.ErrorResponse{
+			Message: "Failed to extract user information",
+			Details: err.Error(),
+		})
+	}
+
+	query := strings.TrimSpace(c.QueryParam("q"))
+	if query == "" {
+		return echo.NewHTTPError(http.StatusBadRequest, dto.ErrorResponse{
+			Message: "Invalid input: search query q is required",
+		})
+	}
+
+	limit := searchDefaultLimit
+	if l := c.QueryParam("limit"); l != "" {
+		limit, err = strconv.Atoi(l)
+		if err != nil || limit <= 0 {
+			return echo.NewHTTPError(http.StatusBadRequest, dto.ErrorResponse{
+				Message: "Invalid limit",
+			})
+		}
+		if limit > searchMaxLimit {
+			limit = searchMaxLimit
+		}
+	}
+
+	var parts []string
+	if t := c.QueryParam("type"); t != "" {
+		source, ok := searchSources[t]
+		if !ok {
+			return echo.NewHTTPError(http.StatusBadRequest, dto.ErrorResponse{
+				Message: "Invalid type",
+				Details: searchSourceOrder,
+			})
+		}
+		parts = append(parts, source)
+	} else {
+		for _, t := range searchSourceOrder {
+			parts = append(parts, searchSources[t])
+		}
+	}
+
+	sql := strings.Join(parts, "\nUNION ALL\n") + "\nORDER BY rank DESC, type, id LIMIT @limit"
+
+	results := []dto.SearchResult{}
+	if err := config.DB.Raw(sql, map[string]interface{}{
+		"cfg":   config.SearchConfig,
+		"opts":  searchHeadlineOpts,
+		"query": query,
+		"user":  userID,
+		"limit": limit,
+	}).Scan(&results).Error; err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, dto.ErrorResponse{
+			Message: "Failed to search",
+			Details: err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, dto.SuccessResponse{
+		Message: "Search completed",
+		Data:    results,
+	})
+}
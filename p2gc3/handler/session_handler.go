@@ -0,0 +1,310 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"p2gc3/config"
+	"p2gc3/dto"
+	helper "p2gc3/helpers"
+	"p2gc3/model"
+	"strconv"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"gorm.io/gorm"
+)
+
+// buildSessionSummary aggregates the logs of a session against its planned workout, volume in unit
+func buildSessionSummary(session model.WorkoutSession, unit string) (dto.WorkoutSessionSummaryResponse, error) {
+	var logs []model.ExerciseLog
+	if err := config.DB.Where("session_id = ?", session.ID).Find(&logs).Error; err != nil {
+		return dto.WorkoutSessionSummaryResponse{}, err
+	}
+
+	var planned []model.Exercise
+	if err := config.DB.Where("workout_id = ?", session.WorkoutID).Find(&planned).Error; err != nil {
+		return dto.WorkoutSessionSummaryResponse{}, err
+	}
+
+	plannedIDs := map[uint]bool{}
+	for _, ex := range planned {
+		plannedIDs[ex.ID] = true
+	}
+
+	// Volume of the working sets, the same tonnage the volume analytics report
+	var totalVolume float64
+	if err := config.DB.Table("exercise_logs").
+		Joins(logSetTotals).
+		Select("COALESCE(SUM("+logTonnageExpr+"), 0)").
+		Where("exercise_logs.session_id = ?", session.ID).
+		Scan(&totalVolume).Error; err != nil {
+		return dto.WorkoutSessionSummaryResponse{}, err
+	}
+
+	completed := map[uint]bool{}
+	for _, l := range logs {
+		if plannedIDs[l.ExerciseID] {
+			completed[l.ExerciseID] = true
+		}
+	}
+
+	end := time.Now()
+	if session.EndedAt != nil {
+		end = *session.EndedAt
+	}
+
+	return dto.WorkoutSessionSummaryResponse{
+		ID:                 session.ID,
+		WorkoutID:          session.WorkoutID,
+		StartedAt:          session.StartedAt,
+		EndedAt:            session.EndedAt,
+		DurationSeconds:    int64(end.Sub(session.StartedAt).Seconds()),
+		TotalVolume:        helper.FromKg(totalVolume, unit),
+		WeightUnit:         unit,
+		LogCount:           len(logs),
+		ExercisesCompleted: len(completed),
+		ExercisesPlanned:   len(planned),
+	}, nil
+}
+
+// findOwnedSession loads a session by the :id path param and checks it belongs to the user
+func findOwnedSession(c echo.Context, userID uint) (model.WorkoutSession, error) {
+	var session model.WorkoutSession
+
+	sessionID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return session, echo.NewHTTPError(http.StatusBadRequest, dto.ErrorResponse{
+			Message: "Invalid session ID",
+			Details: err.Error(),
+		})
+	}
+
+	err = config.DB.First(&session, sessionID).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return session, echo.NewHTTPError(http.StatusNotFound, dto.ErrorResponse{
+			Message: "Session not found",
+		})
+	} else if err != nil {
+		return session, echo.NewHTTPError(http.StatusInternalServerError, dto.ErrorResponse{
+			Message: "Failed to retrieve session",
+			Details: err.Error(),
+		})
+	}
+
+	if session.UserID != userID {
+		return session, echo.NewHTTPError(http.StatusForbidden, dto.ErrorResponse{
+			Message: "You are not authorized to access this session",
+		})
+	}
+
+	return session, nil
+}
+
+// StartWorkoutSession godoc
+// @Summary      Start a workout session
+// @Description  Starts a training session for one of the user's workouts
+// @Tags         sessions
+// @Accept       json
+// @Produce      json
+// @Param        session  body  dto.WorkoutSessionStartRequest  true  "Session payload"
+// @Success      201  {object}  dto.SuccessResponse{data=dto.WorkoutSessionResponse}
+// @Failure      400  {object}  dto.ErrorResponse
+// @Failure      401  {object}  dto.ErrorResponse
+// @Failure      403  {object}  dto.ErrorResponse
+// @Failure      404  {object}  dto.ErrorResponse
+// @Failure      409  {object}  dto.ErrorResponse
+// @Failure      500  {object}  dto.ErrorResponse
+// @Router       /api/sessions [post]
+// @Security     BearerAuth
+func StartWorkoutSession(c echo.Context) error {
+	userID, err := helper.ExtractUserID(c)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusUnauthorized, dto.ErrorResponse{
+			Message: "Failed to extract user information",
+			Details: err.Error(),
+		})
+	}
+
+	var req dto.WorkoutSessionStartRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, dto.ErrorResponse{
+			Message: "Invalid input",
+			Details: err.Error(),
+		})
+	}
+
+	if req.WorkoutID == 0 {
+		return echo.NewHTTPError(http.StatusBadRequest, dto.ErrorResponse{
+			Message: "Invalid input: workout_id is required",
+		})
+	}
+
+	var workout model.Workout
+	err = config.DB.First(&workout, req.WorkoutID).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return echo.NewHTTPError(http.StatusNotFound, dto.ErrorResponse{
+			Message: "Workout not found",
+		})
+	} else if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, dto.ErrorResponse{
+			Message: "Failed to fetch workout",
+			Details: err.Error(),
+		})
+	}
+
+	if workout.UserID != userID {
+		return echo.NewHTTPError(http.StatusForbidden, dto.ErrorResponse{
+			Message: "You are not authorized to start a session for this workout",
+		})
+	}
+
+	// Only one session can be in progress at a time
+	var active int64
+	if err := config.DB.Model(&model.WorkoutSession{}).
+		Where("user_id = ? AND ended_at IS NULL", userID).
+		Count(&active).Error; err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, dto.ErrorResponse{
+			Message: "Failed to check active sessions",
+			Details: err.Error(),
+		})
+	}
+	if active > 0 {
+		return echo.NewHTTPError(http.StatusConflict, dto.ErrorResponse{
+			Message: "Another session is still in progress, finish it first",
+		})
+	}
+
+	session := model.WorkoutSession{
+		UserID:    userID,
+		WorkoutID: workout.ID,
+		StartedAt: time.Now(),
+	}
+
+	if err := config.DB.Create(&session).Error; err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, dto.ErrorResponse{
+			Message: "Failed to start session",
+			Details: err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusCreated, dto.SuccessResponse{
+		Message: "Session started",
+		Data: dto.WorkoutSessionResponse{
+			ID:        session.ID,
+			WorkoutID: session.WorkoutID,
+			StartedAt: session.StartedAt,
+			EndedAt:   session.EndedAt,
+		},
+	})
+}
+
+// FinishWorkoutSession godoc
+// @Summary      Finish a workout session
+// @Description  Marks the session as finished and returns its summary
+// @Tags         sessions
+// @Produce      json
+// @Param        id  path  int  true  "Session ID"
+// @Success      200  {object}  dto.SuccessResponse{data=dto.WorkoutSessionSummaryResponse}
+// @Failure      400  {object}  dto.ErrorResponse
+// @Failure      401  {object}  dto.ErrorResponse
+// @Failure      403  {object}  dto.ErrorResponse
+// @Failure      404  {object}  dto.ErrorResponse
+// @Failure      409  {object}  dto.ErrorResponse
+// @Failure      500  {object}  dto.ErrorResponse
+// @Router       /api/sessions/{id}/finish [post]
+// @Security     BearerAuth
+func FinishWorkoutSession(c echo.Context) error {
+	userID, err := helper.ExtractUserID(c)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusUnauthorized, dto.ErrorResponse{
+			Message: "Unauthorized",
+			Details: err.Error(),
+		})
+	}
+
+	session, err := findOwnedSession(c, userID)
+	if err != nil {
+		return err
+	}
+
+	if session.EndedAt != nil {
+		return echo.NewHTTPError(http.StatusConflict, dto.ErrorResponse{
+			Message: "Session is already finished",
+		})
+	}
+
+	now := time.Now()
+	session.EndedAt = &now
+
+	if err := config.DB.Save(&session).Error; err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, dto.ErrorResponse{
+			Message: "Failed to finish session",
+			Details: err.Error(),
+		})
+	}
+
+	unit, err := userWeightUnit(userID)
+	if err != nil {
+		return err
+	}
+
+	summary, err := buildSessionSummary(session, unit)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, dto.ErrorResponse{
+			Message: "Failed to build session summary",
+			Details: err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, dto.SuccessResponse{
+		Message: "Session finished",
+		Data:    summary,
+	})
+}
+
+// GetWorkoutSession godoc
+// @Summary      Get session summary
+// @Description  Returns duration, total volume and completed vs planned exercises of a session
+// @Tags         sessions
+// @Produce      json
+// @Param        id  path  int  true  "Session ID"
+// @Success      200  {object}  dto.SuccessResponse{data=dto.WorkoutSessionSummaryResponse}
+// @Failure      400  {object}  dto.ErrorResponse
+// @Failure      401  {object}  dto.ErrorResponse
+// @Failure      403  {object}  dto.ErrorResponse
+// @Failure      404  {object}  dto.ErrorResponse
+// @Failure      500  {object}  dto.ErrorResponse
+// @Router       /api/sessions/{id} [get]
+// @Security     BearerAuth
+func GetWorkoutSession(c echo.Context) error {
+	userID, err := helper.ExtractUserID(c)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusUnauthorized, dto.ErrorResponse{
+			Message: "Failed to extract user information",
+			Details: err.Error(),
+		})
+	}
+
+	session, err := findOwnedSession(c, userID)
+	if err != nil {
+		return err
+	}
+
+	unit, err := userWeightUnit(userID)
+	if err != nil {
+		return err
+	}
+
+	summary, err := buildSessionSummary(session, unit)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, dto.ErrorResponse{
+			Message: "Failed to build session summary",
+			Details: err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, dto.SuccessResponse{
+		Message: "Session retrieved",
+		Data:    summary,
+	})
+}
@@ -0,0 +1,258 @@
+package handler
+
+import (
+	"net/http"
+	"p2gc3/config"
+	"p2gc3/dto"
+	helper "p2gc3/helpers"
+	"p2gc3/model"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"gorm.io/gorm"
+)
+
+const alternativesDefaultLimit = 10
+
+func toSubstituteProfile(def model.ExerciseDefinition) helper.SubstituteProfile {
+	entry := toCatalogEntryResponse(def)
+	return helper.SubstituteProfile{
+		Pattern:   entry.MovementPattern,
+		Type:      entry.ExerciseType,
+		Primary:   entry.PrimaryMuscles,
+		Secondary: entry.SecondaryMuscles,
+		Equipment: entry.Equipment,
+	}
+}
+
+// GetExerciseAlternatives godoc
+// @Summary      Suggest substitute exercises
+// @Description  Ranks catalog exercises of the same exercise type that can replace this one by shared muscle groups, movement pattern and available equipment
+// @Tags         exercises
+// @Produce      json
+// @Param        id         path   int     true   "Exercise ID"
+// @Param        equipment  query  string  false  "Comma separated equipment available right now, unrestricted when omitted"
+// @Param        limit      query  int     false  "Max suggestions, defaults to 10"
+// @Success      200  {object}  dto.SuccessResponse{data=[]dto.ExerciseAlternativeResponse}
+// @Failure      400  {object}  dto.ErrorResponse
+// @Failure      401  {object}  dto.ErrorResponse
+// @Failure      403  {object}  dto.ErrorResponse
+// @Failure      404  {object}  dto.ErrorResponse
+// @Failure      500  {object}  dto.ErrorResponse
+// @Router       /api/exercises/{id}/alternatives [get]
+// @Security     BearerAuth
+func GetExerciseAlternatives(c echo.Context) error {
+	userID, err := helper.ExtractUserID(c)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusUnauthorized, dto.ErrorResponse{
+			Message: "Failed to extract user information",
+			Details: err.Error(),
+		})
+	}
+
+	exercise, err := findOwnedExercise(c, userID)
+	if err != nil {
+		return err
+	}
+
+	limit := alternativesDefaultLimit
+	if l := c.QueryParam("limit"); l != "" {
+		limit, err = strconv.Atoi(l)
+		if err != nil || limit <= 0 {
+			return echo.NewHTTPError(http.StatusBadRequest, dto.ErrorResponse{
+				Message: "Invalid limit",
+			})
+		}
+	}
+
+	var available map[string]bool
+	if e := c.QueryParam("equipment"); e != "" {
+		available = map[string]bool{}
+		for _, name := range strings.Split(e, ",") {
+			available[strings.TrimSpace(name)] = true
+		}
+	}
+
+	if exercise.DefinitionID == nil {
+		return echo.NewHTTPError(http.StatusNotFound, dto.ErrorResponse{
+			Message: "Exercise is not linked to the catalog",
+		})
+	}
+
+	var source model.ExerciseDefinition
+	if err := withTaxonomy(config.DB).First(&source, *exercise.DefinitionID).Error; err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, dto.ErrorResponse{
+			Message: "Failed to fetch catalog exercise",
+			Details: err.Error(),
+		})
+	}
+
+	var candidates []model.ExerciseDefinition
+	if err := withTaxonomy(config.DB).
+		Where("(user_id IS NULL OR user_id = ?) AND id <> ? AND exercise_type = ?", userID, source.ID, source.ExerciseType).
+		Find(&candidates).Error; err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, dto.ErrorResponse{
+			Message: "Failed to retrieve catalog",
+			Details: err.Error(),
+		})
+	}
+
+	sourceProfile := toSubstituteProfile(source)
+	response := []dto.ExerciseAlternativeResponse{}
+	for _, cand := range candidates {
+		profile := toSubstituteProfile(cand)
+		if !helper.EquipmentAvailable(profile.Equipment, available) {
+			continue
+		}
+
+		score, reasons := helper.ScoreSubstitute(sourceProfile, profile)
+		if score == 0 {
+			continue
+		}
+
+		response = append(response, dto.ExerciseAlternativeResponse{
+			CatalogEntryResponse: toCatalogEntryResponse(cand),
+			Score:                score,
+			Reasons:              reasons,
+		})
+	}
+
+	sort.SliceStable(response, func(i, j int) bool {
+		if response[i].Score != response[j].Score {
+			return response[i].Score > response[j].Score
+		}
+		return response[i].Name < response[j].Name
+	})
+	if len(response) > limit {
+		response = response[:limit]
+	}
+
+	return c.JSON(http.StatusOK, dto.SuccessResponse{
+		Message: "Success Retrieving Alternatives",
+		Data:    response,
+	})
+}
+
+// SwapExercise godoc
+// @Summary      Swap an exercise for a substitute
+// @Description  Points a workout exercise to another catalog exercise of the same exercise type; existing logs stay attached and keep their original catalog exercise
+// @Tags         exercises
+// @Accept       json
+// @Produce      json
+// @Param        id    path  int                      true  "Exercise ID"
+// @Param        swap  body  dto.ExerciseSwapRequest  true  "Substitute catalog exercise"
+// @Success      200  {object}  dto.SuccessResponse{data=dto.ExerciseSwapResponse}
+// @Failure      400  {object}  dto.ErrorResponse
+// @Failure      401  {object}  dto.ErrorResponse
+// @Failure      403  {object}  dto.ErrorResponse
+// @Failure      404  {object}  dto.ErrorResponse
+// @Failure      500  {object}  dto.ErrorResponse
+// @Router       /api/exercises/{id}/swap [post]
+// @Security     BearerAuth
+func SwapExercise(c echo.Context) error {
+	userID, err := helper.ExtractUserID(c)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusUnauthorized, dto.ErrorResponse{
+			Message: "Failed to extract user information",
+			Details: err.Error(),
+		})
+	}
+
+	exercise, err := findOwnedExercise(c, userID)
+	if err != nil {
+		return err
+	}
+
+	var req dto.ExerciseSwapRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, dto.ErrorResponse{
+			Message: "Invalid request body",
+			Details: err.Error(),
+		})
+	}
+
+	if req.DefinitionID == 0 {
+		return echo.NewHTTPError(http.StatusBadRequest, dto.ErrorResponse{
+			Message: "Invalid input: definition_id is required",
+		})
+	}
+
+	if exercise.DefinitionID != nil && *exercise.DefinitionID == req.DefinitionID {
+		return echo.NewHTTPError(http.StatusBadRequest, dto.ErrorResponse{
+			Message: "Invalid input: exercise already uses this catalog exercise",
+		})
+	}
+
+	target, err := usableDefinition(config.DB, userID, req.DefinitionID)
+	if err != nil {
+		return err
+	}
+
+	// Logs are validated and analysed by type, a substitute has to be logged the same way
+	exerciseType, err := exerciseTypeOf(exercise)
+	if err != nil {
+		return err
+	}
+	if target.ExerciseType != exerciseType {
+		return echo.NewHTTPError(http.StatusBadRequest, dto.ErrorResponse{
+			Message: "Invalid input: a " + exerciseType + " exercise can only be swapped for another " + exerciseType + " exercise",
+		})
+	}
+
+	swap := model.ExerciseSwap{
+		ExerciseID:       exercise.ID,
+		UserID:           userID,
+		FromDefinitionID: exercise.DefinitionID,
+		ToDefinitionID:   target.ID,
+		CreatedAt:        time.Now(),
+	}
+
+	exercise.DefinitionID = &target.ID
+	exercise.Name = target.Name
+	exercise.Description = target.Description
+
+	var logsKept int64
+	err = config.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&model.Exercise{}).Where("id = ?", exercise.ID).Updates(map[string]interface{}{
+			"definition_id": target.ID,
+			"name":          target.Name,
+			"description":   target.Description,
+		}).Error; err != nil {
+			return echo.NewHTTPError(http.StatusInternalServerError, dto.ErrorResponse{
+				Message: "Failed to swap exercise",
+				Details: err.Error(),
+			})
+		}
+
+		if err := tx.Create(&swap).Error; err != nil {
+			return echo.NewHTTPError(http.StatusInternalServerError, dto.ErrorResponse{
+				Message: "Failed to record exercise swap",
+				Details: err.Error(),
+			})
+		}
+
+		if err := tx.Model(&model.ExerciseLog{}).Where("exercise_id = ?", exercise.ID).Count(&logsKept).Error; err != nil {
+			return echo.NewHTTPError(http.StatusInternalServerError, dto.ErrorResponse{
+				Message: "Failed to count exercise logs",
+				Details: err.Error(),
+			})
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, dto.SuccessResponse{
+		Message: "Exercise swapped successfully",
+		Data: dto.ExerciseSwapResponse{
+			Exercise:         toExerciseResponse(exercise),
+			FromDefinitionID: swap.FromDefinitionID,
+			ToDefinitionID:   swap.ToDefinitionID,
+			LogsKept:         logsKept,
+		},
+	})
+}
@@ -0,0 +1,751 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"p2gc3/config"
+	"p2gc3/dto"
+	helper "p2gc3/helpers"
+	"p2gc3/middleware"
+	"p2gc3/model"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+	"gorm.io/gorm"
+)
+
+const (
+	syncPullDefaultLimit  = 500
+	syncPullMaxLimit      = 1000
+	syncPushMaxOperations = 100
+)
+
+// Sync operations and the outcomes of pushing them
+const (
+	syncOpCreate = "create"
+	syncOpUpdate = "update"
+	syncOpDelete = "delete"
+
+	syncApplied  = "applied"
+	syncConflict = "conflict"
+	syncRejected = "rejected"
+)
+
+// syncEntityNames are the entity types clients can sync, with the name used in messages
+var syncEntityNames = map[string]string{
+	model.SyncWorkout:  "Workout",
+	model.SyncExercise: "Exercise",
+	model.SyncLog:      "Log",
+}
+
+// syncConflictError is returned when the server copy of an entity wins over a pushed operation
+type syncConflictError struct {
+	entity  model.SyncEntity
+	message string
+}
+
+func (e *syncConflictError) Error() string {
+	return e.message
+}
+
+// findSyncEntity loads the sync state of one of the user's entities by server ID or, when id is 0,
+// by client ID. found is false when the user has no such entity.
+func findSyncEntity(tx *gorm.DB, userID uint, entityType string, id uint, clientID string) (entity model.SyncEntity, found bool, err error) {
+	query := tx.Where("user_id = ? AND entity_type = ?", userID, entityType)
+	if id != 0 {
+		query = query.Where("entity_id = ?", id)
+	} else {
+		query = query.Where("client_id = ?", clientID)
+	}
+
+	err = query.First(&entity).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return entity, false, nil
+	} else if err != nil {
+		return entity, false, echo.NewHTTPError(http.StatusInternalServerError, dto.ErrorResponse{
+			Message: "Failed to retrieve sync state",
+			Details: err.Error(),
+		})
+	}
+	return entity, true, nil
+}
+
+// reloadSyncEntity reads the sync state the triggers left after a write in tx
+func reloadSyncEntity(tx *gorm.DB, entityType string, id uint) (model.SyncEntity, error) {
+	var entity model.SyncEntity
+	if err := tx.Where("entity_type = ? AND entity_id = ?", entityType, id).First(&entity).Error; err != nil {
+		return entity, echo.NewHTTPError(http.StatusInternalServerError, dto.ErrorResponse{
+			Message: "Failed to retrieve sync state",
+			Details: err.Error(),
+		})
+	}
+	return entity, nil
+}
+
+// syncParent resolves the workout of an exercise or the exercise of a log, sent either as server
+// ID or as client ID. Creating under a parent deleted on the server is rejected.
+func syncParent(tx *gorm.DB, userID uint, entityType string, id uint, clientID string) (uint, error) {
+	name := syncEntityNames[entityType]
+	if id == 0 {
+		if clientID == "" {
+			return 0, echo.NewHTTPError(http.StatusBadRequest, dto.ErrorResponse{
+				Message: "Invalid input: " + entityType + "_id or " + entityType + "_client_id is required",
+			})
+		}
+
+		var err error
+		if clientID, err = helper.NormalizeUUID(clientID); err != nil {
+			return 0, echo.NewHTTPError(http.StatusBadRequest, dto.ErrorResponse{
+				Message: "Invalid " + entityType + "_client_id",
+				Details: err.Error(),
+			})
+		}
+	}
+
+	parent, found, err := findSyncEntity(tx, userID, entityType, id, clientID)
+	if err != nil {
+		return 0, err
+	}
+	if !found {
+		return 0, echo.NewHTTPError(http.StatusNotFound, dto.ErrorResponse{
+			Message: name + " not found",
+		})
+	}
+	if parent.Deleted {
+		return 0, echo.NewHTTPError(http.StatusConflict, dto.ErrorResponse{
+			Message: name + " was deleted",
+		})
+	}
+	return parent.EntityID, nil
+}
+
+// validateSyncOperation checks the shape of an operation and normalizes its client ID
+func validateSyncOperation(op *dto.SyncOperation) error {
+	if op.Op != syncOpCreate && op.Op != syncOpUpdate && op.Op != syncOpDelete {
+		return echo.NewHTTPError(http.StatusBadRequest, dto.ErrorResponse{
+			Message: "Invalid op",
+			Details: "op must be one of create, update, delete",
+		})
+	}
+	if _, ok := syncEntityNames[op.Entity]; !ok {
+		return echo.NewHTTPError(http.StatusBadRequest, dto.ErrorResponse{
+			Message: "Invalid entity",
+			Details: "entity must be one of workout, exercise, log",
+		})
+	}
+
+	if op.ClientID != "" {
+		clientID, err := helper.NormalizeUUID(op.ClientID)
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, dto.ErrorResponse{
+				Message: "Invalid client_id",
+				Details: err.Error(),
+			})
+		}
+		op.ClientID = clientID
+	}
+
+	switch {
+	case op.Op == syncOpCreate && op.ClientID == "":
+		return echo.NewHTTPError(http.StatusBadRequest, dto.ErrorResponse{
+			Message: "Invalid input: client_id is required to create",
+		})
+	case op.Op != syncOpCreate && op.ID == 0 && op.ClientID == "":
+		return echo.NewHTTPError(http.StatusBadRequest, dto.ErrorResponse{
+			Message: "Invalid input: id or client_id is required to " + op.Op,
+		})
+	case op.Op == syncOpUpdate && op.BaseVersion <= 0:
+		return echo.NewHTTPError(http.StatusBadRequest, dto.ErrorResponse{
+			Message: "Invalid input: base_version is required to update",
+		})
+	}
+
+	if op.Op == syncOpDelete {
+		return nil
+	}
+	if (op.Entity == model.SyncWorkout && op.Workout == nil) ||
+		(op.Entity == model.SyncExercise && op.Exercise == nil) ||
+		(op.Entity == model.SyncLog && op.Log == nil) {
+		return echo.NewHTTPError(http.StatusBadRequest, dto.ErrorResponse{
+			Message: "Invalid input: " + op.Entity + " is required to " + op.Op,
+		})
+	}
+	return nil
+}
+
+// syncCreate creates the entity of a create operation and tags it with the client ID. A client ID
+// that is already known is a retried create and returns the existing entity.
+func syncCreate(tx *gorm.DB, userID uint, op dto.SyncOperation) (model.SyncEntity, error) {
+	var existing model.SyncEntity
+	err := tx.Where("user_id = ? AND client_id = ?", userID, op.ClientID).First(&existing).Error
+	if err == nil {
+		if existing.EntityType != op.Entity {
+			return existing, echo.NewHTTPError(http.StatusBadRequest, dto.ErrorResponse{
+				Message: "Invalid client_id: already used by a " + existing.EntityType,
+			})
+		}
+		if existing.Deleted {
+			return existing, &syncConflictError{entity: existing, message: syncEntityNames[op.Entity] + " was deleted"}
+		}
+		return existing, nil
+	} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return existing, echo.NewHTTPError(http.StatusInternalServerError, dto.ErrorResponse{
+			Message: "Failed to retrieve sync state",
+			Details: err.Error(),
+		})
+	}
+
+	var id uint
+	switch op.Entity {
+	case model.SyncWorkout:
+		if op.Workout.Name == "" || op.Workout.Description == "" {
+			return existing, echo.NewHTTPError(http.StatusBadRequest, dto.ErrorResponse{
+				Message: "workout name and description are required",
+			})
+		}
+
+		workout := model.Workout{
+			UserID:      userID,
+			Name:        op.Workout.Name,
+			Description: op.Workout.Description,
+		}
+		if err := tx.Create(&workout).Error; err != nil {
+			return existing, echo.NewHTTPError(http.StatusInternalServerError, dto.ErrorResponse{
+				Message: "Failed to create workout",
+				Details: err.Error(),
+			})
+		}
+		id = workout.ID
+
+	case model.SyncExercise:
+		req := op.Exercise.ExerciseCreateRequest
+		req.WorkoutID, err = syncParent(tx, userID, model.SyncWorkout, req.WorkoutID, op.Exercise.WorkoutClientID)
+		if err != nil {
+			return existing, err
+		}
+
+		exercise, err := newExercise(userID, req)
+		if err != nil {
+			return existing, err
+		}
+		if err := tx.Create(&exercise).Error; err != nil {
+			return existing, echo.NewHTTPError(http.StatusInternalServerError, dto.ErrorResponse{
+				Message: "Failed to create exercise",
+				Details: err.Error(),
+			})
+		}
+		id = exercise.ID
+
+	case model.SyncLog:
+		exerciseID, err := syncParent(tx, userID, model.SyncExercise, op.Log.ExerciseID, op.Log.ExerciseClientID)
+		if err != nil {
+			return existing, err
+		}
+
+		var exercise model.Exercise
+		if err := tx.First(&exercise, exerciseID).Error; err != nil {
+			return existing, echo.NewHTTPError(http.StatusInternalServerError, dto.ErrorResponse{
+				Message: "Failed to retrieve exercise",
+				Details: err.Error(),
+			})
+		}
+
+		log, exerciseType, err := newExerciseLog(userID, exercise, op.Log.ExerciseLogRequest)
+		if err != nil {
+			return existing, err
+		}
+		if err := tx.Create(&log).Error; err != nil {
+			return existing, echo.NewHTTPError(http.StatusInternalServerError, dto.ErrorResponse{
+				Message: "Failed to create log",
+				Details: err.Error(),
+			})
+		}
+		if _, err := detectPersonalRecords(tx, exerciseType, log); err != nil {
+			return existing, echo.NewHTTPError(http.StatusInternalServerError, dto.ErrorResponse{
+				Message: "Failed to create log",
+				Details: err.Error(),
+			})
+		}
+		id = log.ID
+	}
+
+	if err := tx.Model(&model.SyncEntity{}).Where("entity_type = ? AND entity_id = ?", op.Entity, id).
+		Update("client_id", op.ClientID).Error; err != nil {
+		return existing, echo.NewHTTPError(http.StatusInternalServerError, dto.ErrorResponse{
+			Message: "Failed to store client_id",
+			Details: err.Error(),
+		})
+	}
+	return reloadSyncEntity(tx, op.Entity, id)
+}
+
+// syncUpdate applies an update operation. The server copy wins when the entity was deleted or
+// changed after base_version.
+func syncUpdate(tx *gorm.DB, userID uint, op dto.SyncOperation) (model.SyncEntity, error) {
+	name := syncEntityNames[op.Entity]
+	target, found, err := findSyncEntity(tx, userID, op.Entity, op.ID, op.ClientID)
+	if err != nil {
+		return target, err
+	}
+	if !found {
+		return target, echo.NewHTTPError(http.StatusNotFound, dto.ErrorResponse{
+			Message: name + " not found",
+		})
+	}
+	if target.Deleted {
+		return target, &syncConflictError{entity: target, message: name + " was deleted"}
+	}
+	if target.Version > op.BaseVersion {
+		return target, &syncConflictError{entity: target, message: name + " changed since base_version"}
+	}
+
+	switch op.Entity {
+	case model.SyncWorkout:
+		if op.Workout.Name == "" || op.Workout.Description == "" {
+			return target, echo.NewHTTPError(http.StatusBadRequest, dto.ErrorResponse{
+				Message: "workout name and description are required",
+			})
+		}
+
+		if err := tx.Model(&model.Workout{}).Where("id = ?", target.EntityID).Updates(map[string]interface{}{
+			"name":        op.Workout.Name,
+			"description": op.Workout.Description,
+		}).Error; err != nil {
+			return target, echo.NewHTTPError(http.StatusInternalServerError, dto.ErrorResponse{
+				Message: "Failed to update workout",
+				Details: err.Error(),
+			})
+		}
+
+	case model.SyncExercise:
+		if op.Exercise.Name == "" || op.Exercise.Description == "" {
+			return target, echo.NewHTTPError(http.StatusBadRequest, dto.ErrorResponse{
+				Message: "Exercise name and description are required",
+			})
+		}
+
+		var exercise model.Exercise
+		if err := tx.First(&exercise, target.EntityID).Error; err != nil {
+			return target, echo.NewHTTPError(http.StatusInternalServerError, dto.ErrorResponse{
+				Message: "Failed to retrieve exercise",
+				Details: err.Error(),
+			})
+		}
+		if err := relinkExercise(tx, userID, &exercise, op.Exercise.Name, op.Exercise.Description, op.Exercise.DefinitionID); err != nil {
+			return target, err
+		}
+
+		if err := tx.Model(&model.Exercise{}).Where("id = ?", target.EntityID).Updates(map[string]interface{}{
+			"name":          op.Exercise.Name,
+			"description":   op.Exercise.Description,
+			"definition_id": exercise.DefinitionID,
+		}).Error; err != nil {
+			return target, echo.NewHTTPError(http.StatusInternalServerError, dto.ErrorResponse{
+				Message: "Failed to update exercise",
+				Details: err.Error(),
+			})
+		}
+
+	case model.SyncLog:
+		var before model.ExerciseLog
+		if err := withSets(tx.Preload("Exercise")).First(&before, target.EntityID).Error; err != nil {
+			return target, echo.NewHTTPError(http.StatusInternalServerError, dto.ErrorResponse{
+				Message: "Failed to retrieve log",
+				Details: err.Error(),
+			})
+		}
+
+		req := op.Log
+		after, err := replacedLog(userID, before, dto.ExerciseLogUpdateRequest{
+			SetCount:        req.SetCount,
+			RepCount:        req.RepCount,
+			Weight:          req.Weight,
+			WeightUnit:      req.WeightUnit,
+			DurationSeconds: req.DurationSeconds,
+			DistanceMeters:  req.DistanceMeters,
+			AddedWeight:     req.AddedWeight,
+			AssistedWeight:  req.AssistedWeight,
+			Notes:           req.Notes,
+			Tempo:           req.Tempo,
+			RestSeconds:     req.RestSeconds,
+			PerceivedEffort: req.PerceivedEffort,
+			Round:           req.Round,
+			CreatedAt:       req.CreatedAt,
+			Sets:            req.Sets,
+		})
+		if err != nil {
+			return target, err
+		}
+		if _, err := storeLogEdit(tx, userID, before, &after); err != nil {
+			return target, err
+		}
+	}
+
+	return reloadSyncEntity(tx, op.Entity, target.EntityID)
+}
+
+// syncDelete applies a delete operation. Deletes win: they apply even when the entity changed
+// after base_version, and deleting an entity that is already deleted succeeds.
+// It returns the media of deleted exercises, their files are left to the caller.
+func syncDelete(tx *gorm.DB, userID uint, op dto.SyncOperation) (model.SyncEntity, []model.ExerciseMedia, error) {
+	name := syncEntityNames[op.Entity]
+	target, found, err := findSyncEntity(tx, userID, op.Entity, op.ID, op.ClientID)
+	if err != nil {
+		return target, nil, err
+	}
+	if !found {
+		return target, nil, echo.NewHTTPError(http.StatusNotFound, dto.ErrorResponse{
+			Message: name + " not found",
+		})
+	}
+	if target.Deleted {
+		return target, nil, nil
+	}
+
+	var media []model.ExerciseMedia
+	switch op.Entity {
+	case model.SyncWorkout:
+		var workout model.Workout
+		if err := tx.Preload("Exercises").First(&workout, target.EntityID).Error; err != nil {
+			return target, nil, echo.NewHTTPError(http.StatusInternalServerError, dto.ErrorResponse{
+				Message: "Failed to retrieve workout",
+				Details: err.Error(),
+			})
+		}
+		if media, err = deleteWorkoutCascade(tx, workout); err != nil {
+			return target, nil, err
+		}
+
+	case model.SyncExercise:
+		var exercise model.Exercise
+		if err := tx.First(&exercise, target.EntityID).Error; err != nil {
+			return target, nil, echo.NewHTTPError(http.StatusInternalServerError, dto.ErrorResponse{
+				Message: "Failed to retrieve exercise",
+				Details: err.Error(),
+			})
+		}
+		if media, err = deleteExerciseCascade(tx, exercise); err != nil {
+			return target, nil, err
+		}
+
+	case model.SyncLog:
+		if err := config.DeleteLogs(tx, "id = ?", target.EntityID); err != nil {
+			return target, nil, echo.NewHTTPError(http.StatusInternalServerError, dto.ErrorResponse{
+				Message: "Failed to delete log",
+				Details: err.Error(),
+			})
+		}
+	}
+
+	entity, err := reloadSyncEntity(tx, op.Entity, target.EntityID)
+	return entity, media, err
+}
+
+// applySyncOperation applies one pushed operation in its own transaction and reports the outcome
+func applySyncOperation(c echo.Context, userID uint, op dto.SyncOperation) dto.SyncOperationResult {
+	var entity model.SyncEntity
+	var media []model.ExerciseMedia
+
+	err := validateSyncOperation(&op)
+	if err == nil {
+		err = config.DB.Transaction(func(tx *gorm.DB) error {
+			// Holding the user's sync lock keeps versions from changing until the operation commits
+			if err := config.LockSyncUser(tx, userID); err != nil {
+				return echo.NewHTTPError(http.StatusInternalServerError, dto.ErrorResponse{
+					Message: "Failed to lock sync state",
+					Details: err.Error(),
+				})
+			}
+
+			var err error
+			switch op.Op {
+			case syncOpCreate:
+				entity, err = syncCreate(tx, userID, op)
+			case syncOpUpdate:
+				entity, err = syncUpdate(tx, userID, op)
+			case syncOpDelete:
+				entity, media, err = syncDelete(tx, userID, op)
+			}
+			return err
+		})
+	}
+
+	result := dto.SyncOperationResult{
+		Op:       op.Op,
+		Entity:   op.Entity,
+		ClientID: op.ClientID,
+		ID:       op.ID,
+	}
+
+	var conflict *syncConflictError
+	switch {
+	case err == nil:
+		result.Status = syncApplied
+		removeMediaBlobs(c.Request().Context(), media)
+	case errors.As(err, &conflict):
+		result.Status = syncConflict
+		entity = conflict.entity
+		result.Error = &dto.ErrorResponse{
+			Status:  http.StatusConflict,
+			Code:    parseErrorCode(conflict.message, http.StatusConflict),
+			Message: conflict.message,
+		}
+	default:
+		res := toErrorResponse(err)
+		result.Status = syncRejected
+		result.Error = &res
+		if res.Status >= http.StatusInternalServerError {
+			middleware.MakeLogEntry(c).Error("sync operation failed: " + res.Message)
+		}
+		return result
+	}
+
+	result.ID = entity.EntityID
+	result.Version = entity.Version
+	result.Deleted = entity.Deleted
+	if entity.ClientID != nil {
+		result.ClientID = *entity.ClientID
+	}
+	return result
+}
+
+// PullSyncChanges godoc
+// @Summary      Pull changes for offline sync
+// @Description  Returns the workouts, exercises and logs created, updated or deleted since the cursor, oldest change first, each with its version. Deleted entities come back as tombstones; exercises and logs of a deleted workout or exercise may only be removed together with it. Entities are returned in their current state, once even when they changed several times. Start without a cursor for a full sync and keep the returned cursor; pull again while has_more is true.
+// @Tags         sync
+// @Produce      json
+// @Param        cursor       query  string  false  "cursor of the previous pull, omit for a full sync"
+// @Param        limit        query  int     false  "Changes per page, defaults to 500, at most 1000"
+// @Param        weight_unit  query  string  false  "Unit of log weights, kg or lb, defaults to the preferred unit"
+// @Success      200  {object}  dto.SuccessResponse{data=dto.SyncPullResponse}
+// @Failure      400  {object}  dto.ErrorResponse
+// @Failure      401  {object}  dto.ErrorResponse
+// @Failure      500  {object}  dto.ErrorResponse
+// @Router       /api/sync [get]
+// @Security     BearerAuth
+func PullSyncChanges(c echo.Context) error {
+	userID, err := helper.ExtractUserID(c)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusUnauthorized, dto.ErrorResponse{
+			Message: "Failed to extract user information",
+			Details: err.Error(),
+		})
+	}
+
+	since, err := helper.DecodeSyncCursor(c.QueryParam("cursor"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, dto.ErrorResponse{
+			Message: "Invalid cursor",
+			Details: err.Error(),
+		})
+	}
+
+	limit := syncPullDefaultLimit
+	if err := echo.QueryParamsBinder(c).Int("limit", &limit).BindError(); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, dto.ErrorResponse{
+			Message: "Invalid query parameters",
+			Details: err.Error(),
+		})
+	}
+	if limit <= 0 {
+		return echo.NewHTTPError(http.StatusBadRequest, dto.ErrorResponse{
+			Message: "Invalid limit",
+		})
+	}
+	if limit > syncPullMaxLimit {
+		limit = syncPullMaxLimit
+	}
+
+	unit, err := requestWeightUnit(c.QueryParam("weight_unit"), userID)
+	if err != nil {
+		return err
+	}
+
+	var changes []model.SyncEntity
+	if err := config.DB.Where("user_id = ? AND version > ?", userID, since).
+		Order("version").Limit(limit + 1).Find(&changes).Error; err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, dto.ErrorResponse{
+			Message: "Failed to retrieve changes",
+			Details: err.Error(),
+		})
+	}
+
+	response := dto.SyncPullResponse{
+		Cursor:     helper.EncodeSyncCursor(since),
+		WeightUnit: unit,
+		Workouts:   []dto.SyncWorkoutResponse{},
+		Exercises:  []dto.SyncExerciseResponse{},
+		Logs:       []dto.SyncLogResponse{},
+		Deleted:    []dto.SyncTombstoneResponse{},
+	}
+	if len(changes) > limit {
+		changes = changes[:limit]
+		response.HasMore = true
+	}
+	if len(changes) == 0 {
+		return c.JSON(http.StatusOK, dto.SuccessResponse{
+			Message: "Success Retrieving Changes",
+			Data:    response,
+		})
+	}
+	response.Cursor = helper.EncodeSyncCursor(changes[len(changes)-1].Version)
+
+	ids := map[string][]uint{}
+	for _, change := range changes {
+		if !change.Deleted {
+			ids[change.EntityType] = append(ids[change.EntityType], change.EntityID)
+		}
+	}
+
+	workouts := map[uint]model.Workout{}
+	exercises := map[uint]model.Exercise{}
+	logs := map[uint]model.ExerciseLog{}
+	if len(ids[model.SyncWorkout]) > 0 {
+		var found []model.Workout
+		if err := config.DB.Where("id IN ?", ids[model.SyncWorkout]).Find(&found).Error; err != nil {
+			return echo.NewHTTPError(http.StatusInternalServerError, dto.ErrorResponse{
+				Message: "Failed to retrieve workouts",
+				Details: err.Error(),
+			})
+		}
+		for _, w := range found {
+			workouts[w.ID] = w
+		}
+	}
+	if len(ids[model.SyncExercise]) > 0 {
+		var found []model.Exercise
+		if err := config.DB.Where("id IN ?", ids[model.SyncExercise]).Find(&found).Error; err != nil {
+			return echo.NewHTTPError(http.StatusInternalServerError, dto.ErrorResponse{
+				Message: "Failed to retrieve exercises",
+				Details: err.Error(),
+			})
+		}
+		for _, e := range found {
+			exercises[e.ID] = e
+		}
+	}
+	if len(ids[model.SyncLog]) > 0 {
+		var found []model.ExerciseLog
+		if err := withSets(config.DB.Preload("Exercise")).Where("id IN ?", ids[model.SyncLog]).
+			Find(&found).Error; err != nil {
+			return echo.NewHTTPError(http.StatusInternalServerError, dto.ErrorResponse{
+				Message: "Failed to retrieve logs",
+				Details: err.Error(),
+			})
+		}
+		for _, l := range found {
+			logs[l.ID] = l
+		}
+	}
+
+	for _, change := range changes {
+		// An entity deleted after the changes were read is sent as a tombstone too
+		switch change.EntityType {
+		case model.SyncWorkout:
+			if w, ok := workouts[change.EntityID]; ok && !change.Deleted {
+				response.Workouts = append(response.Workouts, dto.SyncWorkoutResponse{
+					ID:          w.ID,
+					ClientID:    change.ClientID,
+					Version:     change.Version,
+					Name:        w.Name,
+					Description: w.Description,
+				})
+				continue
+			}
+		case model.SyncExercise:
+			if e, ok := exercises[change.EntityID]; ok && !change.Deleted {
+				response.Exercises = append(response.Exercises, dto.SyncExerciseResponse{
+					ID:           e.ID,
+					ClientID:     change.ClientID,
+					Version:      change.Version,
+					WorkoutID:    e.WorkoutID,
+					DefinitionID: e.DefinitionID,
+					GroupID:      e.GroupID,
+					GroupOrder:   e.GroupOrder,
+					Name:         e.Name,
+					Description:  e.Description,
+				})
+				continue
+			}
+		case model.SyncLog:
+			if l, ok := logs[change.EntityID]; ok && !change.Deleted {
+				response.Logs = append(response.Logs, dto.SyncLogResponse{
+					ExerciseLogDetailResponse: toExerciseLogDetail(l, unit),
+					ClientID:                  change.ClientID,
+					Version:                   change.Version,
+				})
+				continue
+			}
+		}
+
+		response.Deleted = append(response.Deleted, dto.SyncTombstoneResponse{
+			Entity:    change.EntityType,
+			ID:        change.EntityID,
+			ClientID:  change.ClientID,
+			Version:   change.Version,
+			DeletedAt: change.ChangedAt,
+		})
+	}
+
+	return c.JSON(http.StatusOK, dto.SuccessResponse{
+		Message: "Success Retrieving Changes",
+		Data:    response,
+	})
+}
+
+// PushSyncChanges godoc
+// @Summary      Push changes made offline
+// @Description  Applies operations made offline in the order sent, each on its own, so one failing does not undo the others. Entities created offline carry a client-generated UUID (client_id) that later operations, and exercise_client_id / workout_client_id references, can use before the server ID is known; repeating a create with the same client_id returns the existing entity. Conflicts: an update applies only if the entity has not changed on the server since base_version, otherwise the server copy wins and the operation reports a conflict with the server version; deletes win over updates and apply regardless of base_version; updating an entity deleted on the server, or creating under a deleted workout or exercise, is not applied. Every operation gets a result: applied, conflict or rejected. Pull afterwards to get the server state.
+// @Tags         sync
+// @Accept       json
+// @Produce      json
+// @Param        changes  body  dto.SyncPushRequest  true  "Operations, at most 100"
+// @Success      200  {object}  dto.SuccessResponse{data=dto.SyncPushResponse}
+// @Failure      400  {object}  dto.ErrorResponse
+// @Failure      401  {object}  dto.ErrorResponse
+// @Router       /api/sync [post]
+// @Security     BearerAuth
+func PushSyncChanges(c echo.Context) error {
+	userID, err := helper.ExtractUserID(c)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusUnauthorized, dto.ErrorResponse{
+			Message: "Failed to extract user information",
+			Details: err.Error(),
+		})
+	}
+
+	var req dto.SyncPushRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, dto.ErrorResponse{
+			Message: "Invalid input",
+			Details: err.Error(),
+		})
+	}
+	if len(req.Operations) > syncPushMaxOperations {
+		return echo.NewHTTPError(http.StatusBadRequest, dto.ErrorResponse{
+			Message: "Too many operations",
+			Details: "at most " + strconv.Itoa(syncPushMaxOperations) + " operations can be pushed at once",
+		})
+	}
+
+	response := dto.SyncPushResponse{Results: []dto.SyncOperationResult{}}
+	for i, op := range req.Operations {
+		result := applySyncOperation(c, userID, op)
+		result.Index = i
+
+		switch result.Status {
+		case syncApplied:
+			response.Applied++
+		case syncConflict:
+			response.Conflicts++
+		default:
+			response.Rejected++
+		}
+		response.Results = append(response.Results, result)
+	}
+
+	return c.JSON(http.StatusOK, dto.SuccessResponse{
+		Message: "Sync operations processed",
+		Data:    response,
+	})
+}
@@ -0,0 +1,138 @@
+package handler
+
+import (
+	"net/http"
+	"p2gc3/config"
+	"p2gc3/dto"
+	helper "p2gc3/helpers"
+	"p2gc3/model"
+	"time"
+
+	"github.com/labstack/echo/v4"
+)
+
+// userWeightUnit returns the unit the user wants weights returned in
+func userWeightUnit(userID uint) (string, error) {
+	var u model.User
+	if err := config.DB.Select("id", "weight_unit").First(&u, userID).Error; err != nil {
+		return "", echo.NewHTTPError(http.StatusInternalServerError, dto.ErrorResponse{
+			Message: "Failed to retrieve user preferences",
+			Details: err.Error(),
+		})
+	}
+	if !helper.IsWeightUnit(u.WeightUnit) {
+		return helper.UnitKg, nil
+	}
+	return u.WeightUnit, nil
+}
+
+// userLocation returns the timezone the user's days are counted in
+func userLocation(userID uint) (*time.Location, error) {
+	var u model.User
+	if err := config.DB.Select("id", "timezone").First(&u, userID).Error; err != nil {
+		return nil, echo.NewHTTPError(http.StatusInternalServerError, dto.ErrorResponse{
+			Message: "Failed to retrieve user preferences",
+			Details: err.Error(),
+		})
+	}
+	loc, err := helper.LoadTimezone(u.Timezone)
+	if err != nil {
+		return time.UTC, nil
+	}
+	return loc, nil
+}
+
+// requestWeightUnit resolves the unit of the weights sent in a request,
+// falling back to the preferred unit of the user
+func requestWeightUnit(requested string, userID uint) (string, error) {
+	if requested == "" {
+		return userWeightUnit(userID)
+	}
+	if !helper.IsWeightUnit(requested) {
+		return "", echo.NewHTTPError(http.StatusBadRequest, dto.ErrorResponse{
+			Message: "Invalid weight_unit",
+			Details: "weight_unit must be kg or lb",
+		})
+	}
+	return requested, nil
+}
+
+// UpdateUserPreferences godoc
+// @Summary      Update user preferences
+// @Description  Sets the preferred weight unit and the timezone; weights are stored in kg and returned in the preferred unit, days are counted in the timezone. Omitted fields are left unchanged.
+// @Tags         users
+// @Accept       json
+// @Produce      json
+// @Param        preferences  body  dto.UserPreferencesRequest  true  "Preferences payload"
+// @Success      200  {object}  dto.SuccessResponse{data=dto.UserPreferencesResponse}
+// @Failure      400  {object}  dto.ErrorResponse
+// @Failure      401  {object}  dto.ErrorResponse
+// @Failure      500  {object}  dto.ErrorResponse
+// @Router       /users/preferences [patch]
+// @Security     BearerAuth
+func UpdateUserPreferences(c echo.Context) error {
+	userID, err := helper.ExtractUserID(c)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusUnauthorized, dto.ErrorResponse{
+			Message: "Failed to extract user information",
+			Details: err.Error(),
+		})
+	}
+
+	var req dto.UserPreferencesRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, dto.ErrorResponse{
+			Message: "Invalid input",
+			Details: err.Error(),
+		})
+	}
+
+	if req.WeightUnit == "" && req.Timezone == "" {
+		return echo.NewHTTPError(http.StatusBadRequest, dto.ErrorResponse{
+			Message: "Invalid input: weight_unit or timezone is required",
+		})
+	}
+
+	updates := map[string]interface{}{}
+	if req.WeightUnit != "" {
+		if !helper.IsWeightUnit(req.WeightUnit) {
+			return echo.NewHTTPError(http.StatusBadRequest, dto.ErrorResponse{
+				Message: "Invalid weight_unit",
+				Details: "weight_unit must be kg or lb",
+			})
+		}
+		updates["weight_unit"] = req.WeightUnit
+	}
+	if req.Timezone != "" {
+		if _, err := helper.LoadTimezone(req.Timezone); err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, dto.ErrorResponse{
+				Message: "Invalid timezone",
+				Details: err.Error(),
+			})
+		}
+		updates["timezone"] = req.Timezone
+	}
+
+	if err := config.DB.Model(&model.User{}).Where("id = ?", userID).Updates(updates).Error; err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, dto.ErrorResponse{
+			Message: "Failed to update preferences",
+			Details: err.Error(),
+		})
+	}
+
+	var u model.User
+	if err := config.DB.Select("id", "weight_unit", "timezone").First(&u, userID).Error; err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, dto.ErrorResponse{
+			Message: "Failed to retrieve user preferences",
+			Details: err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, dto.SuccessResponse{
+		Message: "Preferences updated",
+		Data: dto.UserPreferencesResponse{
+			WeightUnit: u.WeightUnit,
+			Timezone:   u.Timezone,
+		},
+	})
+}
@@ -155,7 +155,7 @@ func GetWorkoutByID(c echo.Context) error {
 	}
 
 	var w model.Workout
-	err = config.DB.Preload("Exercises").First(&w, workoutID).Error
+	err = config.DB.Preload("Exercises").Preload("Groups").First(&w, workoutID).Error
 	if errors.Is(err, gorm.ErrRecordNotFound) {
 		return echo.NewHTTPError(http.StatusNotFound, dto.ErrorResponse{
 			Message: "Workout not found",
@@ -176,6 +176,7 @@ func GetWorkoutByID(c echo.Context) error {
 		Description: w.Description,
 		UserID:      w.UserID,
 		Exercises:   w.Exercises,
+		Groups:      buildGroupResponses(w.Groups, w.Exercises),
 	})
 }
 
@@ -296,42 +297,102 @@ func DeleteWorkout(c echo.Context) error {
 		})
 	}
 
-	tx := config.DB.Begin()
+	var media []model.ExerciseMedia
+	err = config.DB.Transaction(func(tx *gorm.DB) error {
+		media, err = deleteWorkoutCascade(tx, workout)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	removeMediaBlobs(c.Request().Context(), media)
+
+	return c.JSON(http.StatusOK, dto.SuccessResponse{
+		Message: "Workout deleted successfully",
+		Data: dto.WorkoutDTO{
+			ID:          workout.ID,
+			Name:        workout.Name,
+			Description: workout.Description,
+		},
+	})
+}
 
-	for _, ex := range workout.Exercises {
-		if err := tx.Where("exercise_id = ?", ex.ID).Delete(&model.ExerciseLog{}).Error; err != nil {
-			tx.Rollback()
-			return echo.NewHTTPError(http.StatusInternalServerError, dto.ErrorResponse{
-				Message: "Failed to delete exercise logs",
-				Details: err.Error(),
-			})
-		}
+// deleteWorkoutCascade deletes a workout with its exercises, logs, sessions and groups in tx and
+// removes it from programs. It returns the media of the exercises, their files are left to the caller.
+func deleteWorkoutCascade(tx *gorm.DB, workout model.Workout) ([]model.ExerciseMedia, error) {
+	exercises := tx.Model(&model.Exercise{}).Select("id").Where("workout_id = ?", workout.ID)
+	var media []model.ExerciseMedia
+	if err := tx.Where("exercise_id IN (?)", exercises).Find(&media).Error; err != nil {
+		return nil, echo.NewHTTPError(http.StatusInternalServerError, dto.ErrorResponse{
+			Message: "Failed to retrieve exercise media",
+			Details: err.Error(),
+		})
+	}
+	if err := tx.Where("exercise_id IN (?)", exercises).Delete(&model.ExerciseMedia{}).Error; err != nil {
+		return nil, echo.NewHTTPError(http.StatusInternalServerError, dto.ErrorResponse{
+			Message: "Failed to delete exercise media",
+			Details: err.Error(),
+		})
+	}
+
+	if err := tx.Where("exercise_id IN (?)", exercises).Delete(&model.ExerciseSwap{}).Error; err != nil {
+		return nil, echo.NewHTTPError(http.StatusInternalServerError, dto.ErrorResponse{
+			Message: "Failed to delete exercise swap history",
+			Details: err.Error(),
+		})
+	}
+
+	if err := config.DeleteLogs(tx, "exercise_id IN (?)", exercises); err != nil {
+		return nil, echo.NewHTTPError(http.StatusInternalServerError, dto.ErrorResponse{
+			Message: "Failed to delete exercise logs",
+			Details: err.Error(),
+		})
 	}
 
 	if err := tx.Where("workout_id = ?", workout.ID).Delete(&model.Exercise{}).Error; err != nil {
-		tx.Rollback()
-		return echo.NewHTTPError(http.StatusInternalServerError, dto.ErrorResponse{
+		return nil, echo.NewHTTPError(http.StatusInternalServerError, dto.ErrorResponse{
 			Message: "Failed to delete exercises",
 			Details: err.Error(),
 		})
 	}
 
+	if err := tx.Where("workout_id = ?", workout.ID).Delete(&model.ProgramDay{}).Error; err != nil {
+		return nil, echo.NewHTTPError(http.StatusInternalServerError, dto.ErrorResponse{
+			Message: "Failed to remove workout from programs",
+			Details: err.Error(),
+		})
+	}
+
+	sessions := tx.Model(&model.WorkoutSession{}).Select("id").Where("workout_id = ?", workout.ID)
+	if err := tx.Model(&model.ExerciseLog{}).Where("session_id IN (?)", sessions).
+		Update("session_id", nil).Error; err != nil {
+		return nil, echo.NewHTTPError(http.StatusInternalServerError, dto.ErrorResponse{
+			Message: "Failed to detach logs from workout sessions",
+			Details: err.Error(),
+		})
+	}
+
+	if err := tx.Where("workout_id = ?", workout.ID).Delete(&model.WorkoutSession{}).Error; err != nil {
+		return nil, echo.NewHTTPError(http.StatusInternalServerError, dto.ErrorResponse{
+			Message: "Failed to delete workout sessions",
+			Details: err.Error(),
+		})
+	}
+
+	if err := tx.Where("workout_id = ?", workout.ID).Delete(&model.ExerciseGroup{}).Error; err != nil {
+		return nil, echo.NewHTTPError(http.StatusInternalServerError, dto.ErrorResponse{
+			Message: "Failed to delete exercise groups",
+			Details: err.Error(),
+		})
+	}
+
 	if err := tx.Delete(&workout).Error; err != nil {
-		tx.Rollback()
-		return echo.NewHTTPError(http.StatusInternalServerError, dto.ErrorResponse{
+		return nil, echo.NewHTTPError(http.StatusInternalServerError, dto.ErrorResponse{
 			Message: "Failed to delete workout",
 			Details: err.Error(),
 		})
 	}
 
-	tx.Commit()
-
-	return c.JSON(http.StatusOK, dto.SuccessResponse{
-		Message: "Workout deleted successfully",
-		Data: dto.WorkoutDTO{
-			ID:          workout.ID,
-			Name:        workout.Name,
-			Description: workout.Description,
-		},
-	})
+	return media, nil
 }
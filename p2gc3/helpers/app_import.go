@@ -0,0 +1,208 @@
+package helper
+
+import (
+	"fmt"
+	"math"
+	"p2gc3/model"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Supported tracking app export formats
+const (
+	AppStrong = "strong"
+	AppHevy   = "hevy"
+)
+
+const metersPerMile = 1609.344
+
+// AppSet is one set row of a tracking app export
+type AppSet struct {
+	Line            int
+	Skip            bool // rows that are not sets, like Strong rest timers
+	Start           time.Time
+	Workout         string
+	Exercise        string
+	SetType         string
+	RepCount        int
+	WeightKg        float64
+	DistanceMeters  int
+	DurationSeconds int
+	RPE             *float64
+	Notes           string
+}
+
+// AppRowParser parses one record of an export, dates without a zone are read in loc
+type AppRowParser func(line int, record []string, loc *time.Location) (AppSet, []string)
+
+// appColumns looks up the required and optional columns of an export header
+func appColumns(app string, header []string, required, optional []string) (map[string]int, error) {
+	index := map[string]int{}
+	for i, h := range header {
+		index[strings.ToLower(strings.TrimSpace(h))] = i
+	}
+
+	columns := map[string]int{}
+	for _, name := range append(append([]string{}, required...), optional...) {
+		if i, ok := index[strings.ToLower(name)]; ok {
+			columns[name] = i
+		}
+	}
+	for _, name := range required {
+		if _, ok := columns[name]; !ok {
+			return nil, fmt.Errorf("not a %s export: missing column %q", app, name)
+		}
+	}
+	return columns, nil
+}
+
+// appRecord reads typed values from one export record, collecting every problem
+type appRecord struct {
+	record   []string
+	columns  map[string]int
+	problems []string
+}
+
+func (r *appRecord) text(column string) string {
+	i, ok := r.columns[column]
+	if !ok || i >= len(r.record) {
+		return ""
+	}
+	return strings.TrimSpace(r.record[i])
+}
+
+func (r *appRecord) number(column string) float64 {
+	v := r.text(column)
+	if v == "" {
+		return 0
+	}
+	f, err := strconv.ParseFloat(v, 64)
+	if err != nil {
+		r.problems = append(r.problems, fmt.Sprintf("%s: %q is not a number", column, v))
+		return 0
+	}
+	return f
+}
+
+func (r *appRecord) optionalNumber(column string) *float64 {
+	if r.text(column) == "" {
+		return nil
+	}
+	f := r.number(column)
+	return &f
+}
+
+func (r *appRecord) date(column string, loc *time.Location, layouts ...string) time.Time {
+	v := r.text(column)
+	for _, layout := range layouts {
+		if t, err := time.ParseInLocation(layout, v, loc); err == nil {
+			return t
+		}
+	}
+	r.problems = append(r.problems, fmt.Sprintf("%s: %q is not a date", column, v))
+	return time.Time{}
+}
+
+// NewStrongParser reads a Strong CSV export. Strong does not export units: weights are read
+// in weightUnit and distances in kilometers, or miles when weightUnit is lb.
+func NewStrongParser(header []string, weightUnit string) (AppRowParser, error) {
+	columns, err := appColumns(AppStrong, header,
+		[]string{"Date", "Workout Name", "Exercise Name", "Set Order", "Weight", "Reps"},
+		[]string{"Distance", "Seconds", "Notes", "RPE"})
+	if err != nil {
+		return nil, err
+	}
+
+	metersPerUnit := 1000.0
+	if weightUnit == UnitLb {
+		metersPerUnit = metersPerMile
+	}
+
+	return func(line int, record []string, loc *time.Location) (AppSet, []string) {
+		r := &appRecord{record: record, columns: columns}
+		set := AppSet{Line: line}
+
+		// Set Order is the set number, or W, D and F for warm-up, drop and failure sets
+		switch order := strings.ToUpper(r.text("Set Order")); order {
+		case "W":
+			set.SetType = model.SetTypeWarmup
+		case "D":
+			set.SetType = model.SetTypeDrop
+		case "F":
+			set.SetType = model.SetTypeFailure
+		case "REST TIMER":
+			set.Skip = true
+			return set, nil
+		default:
+			if _, err := strconv.Atoi(order); err != nil {
+				r.problems = append(r.problems, fmt.Sprintf("Set Order: %q is not a set number, W, D or F", order))
+			}
+			set.SetType = model.SetTypeWorking
+		}
+
+		set.Start = r.date("Date", loc, "2006-01-02 15:04:05", time.RFC3339)
+		set.Workout = r.text("Workout Name")
+		set.Exercise = r.text("Exercise Name")
+		set.RepCount = int(r.number("Reps"))
+		set.WeightKg = ToKg(r.number("Weight"), weightUnit)
+		set.DistanceMeters = int(math.Round(r.number("Distance") * metersPerUnit))
+		set.DurationSeconds = int(r.number("Seconds"))
+		set.RPE = r.optionalNumber("RPE")
+		set.Notes = r.text("Notes")
+		if set.Workout == "" || set.Exercise == "" {
+			r.problems = append(r.problems, "Workout Name and Exercise Name are required")
+		}
+		return set, r.problems
+	}, nil
+}
+
+// NewHevyParser reads a Hevy CSV export, the units come from the weight and distance column names
+func NewHevyParser(header []string) (AppRowParser, error) {
+	columns, err := appColumns(AppHevy, header,
+		[]string{"title", "start_time", "exercise_title", "set_type", "reps"},
+		[]string{"exercise_notes", "weight_kg", "weight_lbs", "distance_km", "distance_miles", "duration_seconds", "rpe"})
+	if err != nil {
+		return nil, err
+	}
+
+	return func(line int, record []string, loc *time.Location) (AppSet, []string) {
+		r := &appRecord{record: record, columns: columns}
+		set := AppSet{Line: line}
+
+		switch t := strings.ToLower(r.text("set_type")); t {
+		case "warmup":
+			set.SetType = model.SetTypeWarmup
+		case "dropset":
+			set.SetType = model.SetTypeDrop
+		case "failure":
+			set.SetType = model.SetTypeFailure
+		case "normal", "":
+			set.SetType = model.SetTypeWorking
+		default:
+			r.problems = append(r.problems, fmt.Sprintf("set_type: unknown set type %q", t))
+		}
+
+		set.Start = r.date("start_time", loc, "2 Jan 2006, 15:04", "2006-01-02 15:04:05", time.RFC3339)
+		set.Workout = r.text("title")
+		set.Exercise = r.text("exercise_title")
+		set.RepCount = int(r.number("reps"))
+		if _, ok := columns["weight_lbs"]; ok {
+			set.WeightKg = ToKg(r.number("weight_lbs"), UnitLb)
+		} else {
+			set.WeightKg = ToKg(r.number("weight_kg"), UnitKg)
+		}
+		if _, ok := columns["distance_miles"]; ok {
+			set.DistanceMeters = int(math.Round(r.number("distance_miles") * metersPerMile))
+		} else {
+			set.DistanceMeters = int(math.Round(r.number("distance_km") * 1000))
+		}
+		set.DurationSeconds = int(r.number("duration_seconds"))
+		set.RPE = r.optionalNumber("rpe")
+		set.Notes = r.text("exercise_notes")
+		if set.Workout == "" || set.Exercise == "" {
+			r.problems = append(r.problems, "title and exercise_title are required")
+		}
+		return set, r.problems
+	}, nil
+}
@@ -15,14 +15,14 @@ type BMIAPIResponse struct {
 	WeightCategory string `json:"weightCategory"`
 }
 
-func GetBMIAndCategory(weight int, heightCm int) (float64, string, error) {
+func GetBMIAndCategory(weight float64, heightCm int) (float64, string, error) {
 	apiKey := os.Getenv("RAPIDAPI_KEY")
 	if apiKey == "" {
 		return 0, "", fmt.Errorf("RAPIDAPI_KEY not set in env")
 	}
 
 	heightMeters := float64(heightCm) / 100
-	bmiURL := fmt.Sprintf("https://body-mass-index-bmi-calculator.p.rapidapi.com/metric?weight=%d&height=%.2f", weight, heightMeters)
+	bmiURL := fmt.Sprintf("https://body-mass-index-bmi-calculator.p.rapidapi.com/metric?weight=%.1f&height=%.2f", weight, heightMeters)
 
 	req, _ := http.NewRequest("GET", bmiURL, nil)
 	req.Header.Add("x-rapidapi-host", "body-mass-index-bmi-calculator.p.rapidapi.com")
@@ -0,0 +1,59 @@
+package helper
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+)
+
+// pageCursor is the keyset position of the last item of a page
+type pageCursor struct {
+	Value string `json:"v"`
+	ID    uint   `json:"id"`
+}
+
+// EncodeCursor builds an opaque cursor from the sort value and ID of the last returned item
+func EncodeCursor(value string, id uint) string {
+	b, _ := json.Marshal(pageCursor{Value: value, ID: id})
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+// DecodeCursor reverses EncodeCursor
+func DecodeCursor(cursor string) (string, uint, error) {
+	b, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return "", 0, errors.New("malformed cursor")
+	}
+	var c pageCursor
+	if err := json.Unmarshal(b, &c); err != nil || c.ID == 0 {
+		return "", 0, errors.New("malformed cursor")
+	}
+	return c.Value, c.ID, nil
+}
+
+// syncCursor is the last change version a client has pulled
+type syncCursor struct {
+	Version int64 `json:"s"`
+}
+
+// EncodeSyncCursor builds an opaque sync cursor from a change version
+func EncodeSyncCursor(version int64) string {
+	b, _ := json.Marshal(syncCursor{Version: version})
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+// DecodeSyncCursor reverses EncodeSyncCursor, an empty cursor starts from the beginning
+func DecodeSyncCursor(cursor string) (int64, error) {
+	if cursor == "" {
+		return 0, nil
+	}
+	b, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return 0, errors.New("malformed cursor")
+	}
+	var c syncCursor
+	if err := json.Unmarshal(b, &c); err != nil || c.Version < 0 {
+		return 0, errors.New("malformed cursor")
+	}
+	return c.Version, nil
+}
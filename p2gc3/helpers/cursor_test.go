@@ -0,0 +1,82 @@
+package helper
+
+import (
+	"encoding/base64"
+	"testing"
+)
+
+func TestPageCursorRoundTrip(t *testing.T) {
+	tests := []struct {
+		name  string
+		value string
+		id    uint
+	}{
+		{"timestamp", "2026-01-05T10:00:00.123456Z", 42},
+		{"empty sort value", "", 1},
+		{"name with separators", "Bench Press, close grip: 3/4", 7},
+		{"largest ID", "x", ^uint(0)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			value, id, err := DecodeCursor(EncodeCursor(tt.value, tt.id))
+			if err != nil {
+				t.Fatalf("DecodeCursor: %v", err)
+			}
+			if value != tt.value || id != tt.id {
+				t.Errorf("round trip = (%q, %d), want (%q, %d)", value, id, tt.value, tt.id)
+			}
+		})
+	}
+}
+
+func TestDecodeCursorRejectsMalformed(t *testing.T) {
+	encode := func(s string) string { return base64.RawURLEncoding.EncodeToString([]byte(s)) }
+
+	for name, cursor := range map[string]string{
+		"not base64":    "%%%",
+		"not JSON":      encode("v=1"),
+		"missing ID":    encode(`{"v":"x"}`),
+		"wrong ID type": encode(`{"v":"x","id":"7"}`),
+		"empty":         "",
+	} {
+		t.Run(name, func(t *testing.T) {
+			if _, _, err := DecodeCursor(cursor); err == nil {
+				t.Errorf("DecodeCursor(%q) accepted a malformed cursor", cursor)
+			}
+		})
+	}
+}
+
+func TestSyncCursorRoundTrip(t *testing.T) {
+	for _, version := range []int64{0, 1, 987654321, 1<<63 - 1} {
+		got, err := DecodeSyncCursor(EncodeSyncCursor(version))
+		if err != nil {
+			t.Fatalf("DecodeSyncCursor(%d): %v", version, err)
+		}
+		if got != version {
+			t.Errorf("round trip of %d = %d", version, got)
+		}
+	}
+
+	if got, err := DecodeSyncCursor(""); err != nil || got != 0 {
+		t.Errorf("DecodeSyncCursor(\"\") = %d, %v, want a full sync from 0", got, err)
+	}
+}
+
+func TestDecodeSyncCursorRejectsMalformed(t *testing.T) {
+	encode := func(s string) string { return base64.RawURLEncoding.EncodeToString([]byte(s)) }
+
+	for name, cursor := range map[string]string{
+		"not base64":       "%%%",
+		"not JSON":         encode("s=1"),
+		"negative version": EncodeSyncCursor(-1),
+		"wrong type":       encode(`{"s":"1"}`),
+	} {
+		t.Run(name, func(t *testing.T) {
+			if _, err := DecodeSyncCursor(cursor); err == nil {
+				t.Errorf("DecodeSyncCursor(%q) accepted a malformed cursor", cursor)
+			}
+		})
+	}
+}
@@ -0,0 +1,234 @@
+package helper
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Supported export formats
+const (
+	ExportCSV  = "csv"
+	ExportJSON = "json"
+	ExportXLSX = "xlsx"
+)
+
+// ExportColumns is the column layout of every export format, one log per row. Columns are only
+// ever added at the end so positions stay stable. In CSV, text cells starting with =, +, -, @,
+// a tab or a carriage return get a leading ' so spreadsheets do not run them as formulas.
+var ExportColumns = []string{
+	"log_id",
+	"logged_at", // RFC3339 in UTC
+	"workout_id",
+	"workout_name",
+	"exercise_id",
+	"exercise_name",
+	"exercise_type",
+	"session_id", // empty when logged outside a session
+	"set_count",
+	"rep_count",
+	"weight",
+	"added_weight",
+	"assisted_weight",
+	"weight_unit", // unit of the three weight columns
+	"duration_seconds",
+	"distance_meters",
+	"pace_seconds_per_km",
+	"rest_seconds",
+	"tempo",
+	"perceived_effort", // empty when not recorded
+	"notes",
+}
+
+// ExportRow is one exported log, its JSON keys match ExportColumns
+type ExportRow struct {
+	LogID            uint      `json:"log_id"`
+	LoggedAt         time.Time `json:"logged_at"`
+	WorkoutID        uint      `json:"workout_id"`
+	WorkoutName      string    `json:"workout_name"`
+	ExerciseID       uint      `json:"exercise_id"`
+	ExerciseName     string    `json:"exercise_name"`
+	ExerciseType     string    `json:"exercise_type"`
+	SessionID        *uint     `json:"session_id"`
+	SetCount         int       `json:"set_count"`
+	RepCount         int       `json:"rep_count"`
+	Weight           float64   `json:"weight"`
+	AddedWeight      float64   `json:"added_weight"`
+	AssistedWeight   float64   `json:"assisted_weight"`
+	WeightUnit       string    `json:"weight_unit"`
+	DurationSeconds  int       `json:"duration_seconds"`
+	DistanceMeters   int       `json:"distance_meters"`
+	PaceSecondsPerKm int       `json:"pace_seconds_per_km"`
+	RestSeconds      int       `json:"rest_seconds"`
+	Tempo            string    `json:"tempo"`
+	PerceivedEffort  *float64  `json:"perceived_effort"`
+	Notes            string    `json:"notes"`
+}
+
+// Values returns the row in ExportColumns order as strings, ints, float64s or nil for empty cells
+func (r ExportRow) Values() []interface{} {
+	var session, effort interface{}
+	if r.SessionID != nil {
+		session = int(*r.SessionID)
+	}
+	if r.PerceivedEffort != nil {
+		effort = *r.PerceivedEffort
+	}
+
+	return []interface{}{
+		int(r.LogID),
+		r.LoggedAt.UTC().Format(time.RFC3339),
+		int(r.WorkoutID),
+		r.WorkoutName,
+		int(r.ExerciseID),
+		r.ExerciseName,
+		r.ExerciseType,
+		session,
+		r.SetCount,
+		r.RepCount,
+		r.Weight,
+		r.AddedWeight,
+		r.AssistedWeight,
+		r.WeightUnit,
+		r.DurationSeconds,
+		r.DistanceMeters,
+		r.PaceSecondsPerKm,
+		r.RestSeconds,
+		r.Tempo,
+		effort,
+		r.Notes,
+	}
+}
+
+// formatExportValue renders a cell of ExportRow.Values as text
+func formatExportValue(v interface{}) string {
+	switch v := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case int:
+		return strconv.Itoa(v)
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	}
+	return ""
+}
+
+// ExportWriter writes export rows to an underlying writer as they come, without holding them in memory
+type ExportWriter interface {
+	WriteRow(row ExportRow) error
+	// Flush pushes buffered rows to the underlying writer
+	Flush() error
+	// Close finishes the document, the underlying writer is left open
+	Close() error
+}
+
+// NewExportWriter starts an export of the given format on w
+func NewExportWriter(format string, w io.Writer) (ExportWriter, error) {
+	switch format {
+	case ExportJSON:
+		return newJSONExportWriter(w)
+	case ExportXLSX:
+		return newXLSXExportWriter(w)
+	default:
+		return newCSVExportWriter(w)
+	}
+}
+
+// ExportContentType returns the MIME type of an export format
+func ExportContentType(format string) string {
+	switch format {
+	case ExportJSON:
+		return "application/json"
+	case ExportXLSX:
+		return "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+	default:
+		return "text/csv; charset=utf-8"
+	}
+}
+
+// csvFormulaPrefixes are the first characters spreadsheets read a cell as a formula from
+const csvFormulaPrefixes = "=+-@\t\r"
+
+// csvSafeText neutralizes free text that a spreadsheet would run as a formula
+func csvSafeText(s string) string {
+	if s != "" && strings.ContainsRune(csvFormulaPrefixes, rune(s[0])) {
+		return "'" + s
+	}
+	return s
+}
+
+type csvExportWriter struct {
+	w      *csv.Writer
+	record []string
+}
+
+func newCSVExportWriter(w io.Writer) (*csvExportWriter, error) {
+	cw := &csvExportWriter{w: csv.NewWriter(w), record: make([]string, len(ExportColumns))}
+	if err := cw.w.Write(ExportColumns); err != nil {
+		return nil, err
+	}
+	return cw, nil
+}
+
+func (cw *csvExportWriter) WriteRow(row ExportRow) error {
+	for i, v := range row.Values() {
+		if text, ok := v.(string); ok {
+			cw.record[i] = csvSafeText(text)
+		} else {
+			cw.record[i] = formatExportValue(v)
+		}
+	}
+	return cw.w.Write(cw.record)
+}
+
+func (cw *csvExportWriter) Flush() error {
+	cw.w.Flush()
+	return cw.w.Error()
+}
+
+func (cw *csvExportWriter) Close() error {
+	return cw.Flush()
+}
+
+// jsonExportWriter writes a JSON array of row objects, one element at a time
+type jsonExportWriter struct {
+	w     io.Writer
+	first bool
+}
+
+func newJSONExportWriter(w io.Writer) (*jsonExportWriter, error) {
+	if _, err := io.WriteString(w, "["); err != nil {
+		return nil, err
+	}
+	return &jsonExportWriter{w: w, first: true}, nil
+}
+
+func (jw *jsonExportWriter) WriteRow(row ExportRow) error {
+	row.LoggedAt = row.LoggedAt.UTC().Truncate(time.Second)
+	b, err := json.Marshal(row)
+	if err != nil {
+		return err
+	}
+	if !jw.first {
+		if _, err := io.WriteString(jw.w, ",\n"); err != nil {
+			return err
+		}
+	}
+	jw.first = false
+	_, err = jw.w.Write(b)
+	return err
+}
+
+func (jw *jsonExportWriter) Flush() error {
+	return nil
+}
+
+func (jw *jsonExportWriter) Close() error {
+	_, err := io.WriteString(jw.w, "]\n")
+	return err
+}
@@ -0,0 +1,53 @@
+package helper
+
+import (
+	"bytes"
+	"encoding/csv"
+	"testing"
+	"time"
+)
+
+func TestCSVExportNeutralizesFormulas(t *testing.T) {
+	tests := []struct {
+		notes string
+		want  string
+	}{
+		{"=HYPERLINK(\"http://example.com\")", "'=HYPERLINK(\"http://example.com\")"},
+		{"+1 rep next time", "'+1 rep next time"},
+		{"-5 kg drop", "'-5 kg drop"},
+		{"@coach check form", "'@coach check form"},
+		{"\tindented", "'\tindented"},
+		{"felt easy", "felt easy"},
+		{"", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.notes, func(t *testing.T) {
+			var buf bytes.Buffer
+			w, err := newCSVExportWriter(&buf)
+			if err != nil {
+				t.Fatalf("newCSVExportWriter: %v", err)
+			}
+			row := ExportRow{LogID: 1, LoggedAt: time.Date(2026, 1, 5, 10, 0, 0, 0, time.UTC), Weight: -2.5, Notes: tt.notes}
+			if err := w.WriteRow(row); err != nil {
+				t.Fatalf("WriteRow: %v", err)
+			}
+			if err := w.Close(); err != nil {
+				t.Fatalf("Close: %v", err)
+			}
+
+			records, err := csv.NewReader(&buf).ReadAll()
+			if err != nil {
+				t.Fatalf("reading the export: %v", err)
+			}
+			cells := records[1]
+			if got := cells[len(cells)-1]; got != tt.want {
+				t.Errorf("notes cell = %q, want %q", got, tt.want)
+			}
+			// Numbers are never prefixed, only free text is
+			if got := cells[10]; got != "-2.5" {
+				t.Errorf("weight cell = %q, want -2.5", got)
+			}
+		})
+	}
+}
@@ -0,0 +1,175 @@
+package helper
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Fields a CSV import can map columns to, date, workout and exercise are required
+const (
+	ImportFieldDate            = "date"
+	ImportFieldWorkout         = "workout"
+	ImportFieldExercise        = "exercise"
+	ImportFieldSetCount        = "set_count"
+	ImportFieldRepCount        = "rep_count"
+	ImportFieldWeight          = "weight"
+	ImportFieldDurationSeconds = "duration_seconds"
+	ImportFieldDistanceMeters  = "distance_meters"
+	ImportFieldNotes           = "notes"
+	ImportFieldTempo           = "tempo"
+	ImportFieldRestSeconds     = "rest_seconds"
+	ImportFieldPerceivedEffort = "perceived_effort"
+)
+
+// ImportFields lists every field a CSV column can be mapped to
+var ImportFields = []string{
+	ImportFieldDate, ImportFieldWorkout, ImportFieldExercise,
+	ImportFieldSetCount, ImportFieldRepCount, ImportFieldWeight,
+	ImportFieldDurationSeconds, ImportFieldDistanceMeters,
+	ImportFieldNotes, ImportFieldTempo, ImportFieldRestSeconds, ImportFieldPerceivedEffort,
+}
+
+var importRequiredFields = []string{ImportFieldDate, ImportFieldWorkout, ImportFieldExercise}
+
+// importDateLayouts are tried in order when parsing the date column
+var importDateLayouts = []string{
+	time.RFC3339,
+	"2006-01-02 15:04:05",
+	"2006-01-02 15:04",
+	"2006-01-02",
+}
+
+// ImportRow is one parsed CSV row, weights are in the unit of the import
+type ImportRow struct {
+	Line            int // 1-based line in the file, the header is line 1
+	Date            time.Time
+	Workout         string
+	Exercise        string
+	SetCount        int
+	RepCount        int
+	Weight          float64
+	DurationSeconds int
+	DistanceMeters  int
+	Notes           string
+	Tempo           string
+	RestSeconds     int
+	PerceivedEffort *float64
+}
+
+// ResolveImportColumns maps every import field to its column index in header.
+// mapping goes from field to header name; unmapped fields default to a header with the
+// field name itself, compared case-insensitively.
+func ResolveImportColumns(header []string, mapping map[string]string) (map[string]int, error) {
+	known := map[string]bool{}
+	for _, f := range ImportFields {
+		known[f] = true
+	}
+	for field := range mapping {
+		if !known[field] {
+			return nil, fmt.Errorf("unknown field %q in mapping", field)
+		}
+	}
+
+	index := map[string]int{}
+	for i, h := range header {
+		index[strings.ToLower(strings.TrimSpace(h))] = i
+	}
+
+	columns := map[string]int{}
+	for _, field := range ImportFields {
+		name := field
+		if m, ok := mapping[field]; ok {
+			name = m
+		}
+		if i, ok := index[strings.ToLower(strings.TrimSpace(name))]; ok {
+			columns[field] = i
+		} else if _, mapped := mapping[field]; mapped {
+			return nil, fmt.Errorf("column %q mapped to %s is not in the header", name, field)
+		}
+	}
+
+	for _, field := range importRequiredFields {
+		if _, ok := columns[field]; !ok {
+			return nil, fmt.Errorf("no column for required field %s", field)
+		}
+	}
+	return columns, nil
+}
+
+// ParseImportRow reads one CSV record, dates without a zone are read in loc.
+// It returns every problem found rather than stopping at the first.
+func ParseImportRow(line int, record []string, columns map[string]int, loc *time.Location) (ImportRow, []string) {
+	row := ImportRow{Line: line}
+	var problems []string
+
+	value := func(field string) string {
+		i, ok := columns[field]
+		if !ok || i >= len(record) {
+			return ""
+		}
+		return strings.TrimSpace(record[i])
+	}
+	integer := func(field string, dst *int) {
+		if v := value(field); v != "" {
+			n, err := strconv.Atoi(v)
+			if err != nil {
+				problems = append(problems, fmt.Sprintf("%s: %q is not a whole number", field, v))
+				return
+			}
+			*dst = n
+		}
+	}
+	decimal := func(field string) *float64 {
+		v := value(field)
+		if v == "" {
+			return nil
+		}
+		f, err := strconv.ParseFloat(strings.Replace(v, ",", ".", 1), 64)
+		if err != nil {
+			problems = append(problems, fmt.Sprintf("%s: %q is not a number", field, v))
+			return nil
+		}
+		return &f
+	}
+
+	if v := value(ImportFieldDate); v == "" {
+		problems = append(problems, "date is required")
+	} else {
+		parsed := false
+		for _, layout := range importDateLayouts {
+			if t, err := time.ParseInLocation(layout, v, loc); err == nil {
+				row.Date = t
+				parsed = true
+				break
+			}
+		}
+		if !parsed {
+			problems = append(problems, fmt.Sprintf("date: %q is not a date (YYYY-MM-DD, YYYY-MM-DD HH:MM[:SS] or RFC3339)", v))
+		}
+	}
+
+	row.Workout = value(ImportFieldWorkout)
+	if row.Workout == "" {
+		problems = append(problems, "workout is required")
+	}
+	row.Exercise = value(ImportFieldExercise)
+	if row.Exercise == "" {
+		problems = append(problems, "exercise is required")
+	}
+
+	integer(ImportFieldSetCount, &row.SetCount)
+	integer(ImportFieldRepCount, &row.RepCount)
+	if w := decimal(ImportFieldWeight); w != nil {
+		row.Weight = *w
+	}
+	integer(ImportFieldDurationSeconds, &row.DurationSeconds)
+	integer(ImportFieldDistanceMeters, &row.DistanceMeters)
+	row.Notes = value(ImportFieldNotes)
+	row.Tempo = NormalizeTempo(value(ImportFieldTempo))
+	integer(ImportFieldRestSeconds, &row.RestSeconds)
+	row.PerceivedEffort = decimal(ImportFieldPerceivedEffort)
+
+	return row, problems
+}
@@ -0,0 +1,76 @@
+package helper
+
+import (
+	"fmt"
+	"p2gc3/model"
+)
+
+// SetValues are the measurable fields of one set of a log
+type SetValues struct {
+	SetType  string
+	RepCount int
+	Weight   float64
+	RPE      *float64
+	RIR      *int
+}
+
+// ValidateSets checks every set of a log and that at least one of them is not a warm-up
+func ValidateSets(sets []SetValues) error {
+	counted := 0
+	for i, s := range sets {
+		n := i + 1
+		switch s.SetType {
+		case model.SetTypeWarmup:
+		case model.SetTypeWorking, model.SetTypeDrop, model.SetTypeFailure:
+			counted++
+		default:
+			return fmt.Errorf("set %d: unknown set type %q", n, s.SetType)
+		}
+		if s.RepCount <= 0 {
+			return fmt.Errorf("set %d: rep_count must be positive", n)
+		}
+		if s.Weight < 0 {
+			return fmt.Errorf("set %d: weight cannot be negative", n)
+		}
+		if s.RPE != nil && (*s.RPE < 1 || *s.RPE > 10) {
+			return fmt.Errorf("set %d: rpe must be between 1 and 10", n)
+		}
+		if s.RIR != nil && *s.RIR < 0 {
+			return fmt.Errorf("set %d: rir cannot be negative", n)
+		}
+	}
+	if counted == 0 {
+		return fmt.Errorf("at least one set must not be a warm-up")
+	}
+	return nil
+}
+
+// AggregateSets derives the aggregate set_count, rep_count and weight of a log from its sets.
+// Warm-ups are left out, reps and weight come from the heaviest set (most reps on ties).
+func AggregateSets(sets []SetValues) (setCount, repCount int, weight float64) {
+	for _, s := range sets {
+		if s.SetType == model.SetTypeWarmup {
+			continue
+		}
+		setCount++
+		if s.Weight > weight || (s.Weight == weight && s.RepCount > repCount) {
+			weight = s.Weight
+			repCount = s.RepCount
+		}
+	}
+	return setCount, repCount, weight
+}
+
+// HardestRPE returns the highest RPE logged on a set that is not a warm-up, nil when none has one
+func HardestRPE(sets []SetValues) *float64 {
+	var hardest *float64
+	for _, s := range sets {
+		if s.SetType == model.SetTypeWarmup || s.RPE == nil {
+			continue
+		}
+		if hardest == nil || *s.RPE > *hardest {
+			hardest = s.RPE
+		}
+	}
+	return hardest
+}
@@ -0,0 +1,128 @@
+package helper
+
+import (
+	"p2gc3/model"
+	"strings"
+	"testing"
+)
+
+func TestValidateSets(t *testing.T) {
+	rpe := func(v float64) *float64 { return &v }
+	rir := func(v int) *int { return &v }
+
+	tests := []struct {
+		name    string
+		sets    []SetValues
+		wantErr string
+	}{
+		{
+			name: "valid",
+			sets: []SetValues{
+				{SetType: model.SetTypeWarmup, RepCount: 10, Weight: 40},
+				{SetType: model.SetTypeWorking, RepCount: 5, Weight: 100, RPE: rpe(8), RIR: rir(2)},
+				{SetType: model.SetTypeDrop, RepCount: 8, Weight: 80},
+				{SetType: model.SetTypeFailure, RepCount: 3, Weight: 80, RPE: rpe(10), RIR: rir(0)},
+			},
+		},
+		{"only warm-ups", []SetValues{{SetType: model.SetTypeWarmup, RepCount: 10, Weight: 40}}, "not be a warm-up"},
+		{"unknown set type", []SetValues{{SetType: "cluster", RepCount: 5, Weight: 100}}, "unknown set type"},
+		{"no reps", []SetValues{{SetType: model.SetTypeWorking, Weight: 100}}, "rep_count"},
+		{"negative weight", []SetValues{{SetType: model.SetTypeWorking, RepCount: 5, Weight: -1}}, "weight"},
+		{"rpe below 1", []SetValues{{SetType: model.SetTypeWorking, RepCount: 5, RPE: rpe(0.5)}}, "rpe"},
+		{"rpe above 10", []SetValues{{SetType: model.SetTypeWorking, RepCount: 5, RPE: rpe(10.5)}}, "rpe"},
+		{"negative rir", []SetValues{{SetType: model.SetTypeWorking, RepCount: 5, RIR: rir(-1)}}, "rir"},
+		{
+			name: "errors name the set",
+			sets: []SetValues{
+				{SetType: model.SetTypeWorking, RepCount: 5},
+				{SetType: model.SetTypeWorking, RepCount: 0},
+			},
+			wantErr: "set 2",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateSets(tt.sets)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("ValidateSets: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("ValidateSets error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestAggregateSets(t *testing.T) {
+	tests := []struct {
+		name     string
+		sets     []SetValues
+		setCount int
+		repCount int
+		weight   float64
+	}{
+		{
+			name: "warm-ups are left out",
+			sets: []SetValues{
+				{SetType: model.SetTypeWarmup, RepCount: 10, Weight: 120},
+				{SetType: model.SetTypeWorking, RepCount: 5, Weight: 100},
+				{SetType: model.SetTypeWorking, RepCount: 5, Weight: 100},
+			},
+			setCount: 2, repCount: 5, weight: 100,
+		},
+		{
+			name: "heaviest set wins",
+			sets: []SetValues{
+				{SetType: model.SetTypeWorking, RepCount: 8, Weight: 90},
+				{SetType: model.SetTypeWorking, RepCount: 3, Weight: 110},
+				{SetType: model.SetTypeDrop, RepCount: 12, Weight: 70},
+			},
+			setCount: 3, repCount: 3, weight: 110,
+		},
+		{
+			name: "most reps on a tie",
+			sets: []SetValues{
+				{SetType: model.SetTypeWorking, RepCount: 5, Weight: 100},
+				{SetType: model.SetTypeFailure, RepCount: 7, Weight: 100},
+			},
+			setCount: 2, repCount: 7, weight: 100,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			setCount, repCount, weight := AggregateSets(tt.sets)
+			if setCount != tt.setCount || repCount != tt.repCount || weight != tt.weight {
+				t.Errorf("AggregateSets = %d sets, %d reps, %v, want %d sets, %d reps, %v",
+					setCount, repCount, weight, tt.setCount, tt.repCount, tt.weight)
+			}
+		})
+	}
+}
+
+func TestHardestRPE(t *testing.T) {
+	rpe := func(v float64) *float64 { return &v }
+
+	tests := []struct {
+		name string
+		sets []SetValues
+		want *float64
+	}{
+		{"no rpe logged", []SetValues{{SetType: model.SetTypeWorking}}, nil},
+		{"warm-ups are left out", []SetValues{{SetType: model.SetTypeWarmup, RPE: rpe(9)}, {SetType: model.SetTypeWorking, RPE: rpe(7)}}, rpe(7)},
+		{"highest of the sets", []SetValues{{SetType: model.SetTypeWorking, RPE: rpe(7)}, {SetType: model.SetTypeWorking}, {SetType: model.SetTypeFailure, RPE: rpe(9.5)}}, rpe(9.5)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := HardestRPE(tt.sets)
+			if (got == nil) != (tt.want == nil) || (got != nil && *got != *tt.want) {
+				t.Errorf("HardestRPE = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,128 @@
+package helper
+
+import (
+	"errors"
+	"fmt"
+	"p2gc3/model"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+)
+
+// LogValues are the measurable fields of an exercise log
+type LogValues struct {
+	SetCount        int
+	RepCount        int
+	Weight          float64
+	DurationSeconds int
+	DistanceMeters  int
+	AddedWeight     float64
+	AssistedWeight  float64
+}
+
+// ValidateLogForType checks that a log carries the fields required by the exercise type
+// and none that do not apply to it
+func ValidateLogForType(exerciseType string, v LogValues) error {
+	if v.SetCount < 0 || v.RepCount < 0 || v.Weight < 0 || v.DurationSeconds < 0 ||
+		v.DistanceMeters < 0 || v.AddedWeight < 0 || v.AssistedWeight < 0 {
+		return errors.New("values cannot be negative")
+	}
+
+	switch exerciseType {
+	case model.TypeStrength, "":
+		if v.SetCount == 0 || v.RepCount == 0 || v.Weight == 0 {
+			return errors.New("strength logs require set_count, rep_count and weight")
+		}
+		if v.DistanceMeters != 0 || v.AddedWeight != 0 || v.AssistedWeight != 0 {
+			return errors.New("strength logs do not take distance, added or assisted weight")
+		}
+
+	case model.TypeBodyweight:
+		if v.SetCount == 0 || v.RepCount == 0 {
+			return errors.New("bodyweight logs require set_count and rep_count")
+		}
+		if v.Weight != 0 {
+			return errors.New("bodyweight logs use added_weight or assisted_weight instead of weight")
+		}
+		if v.AddedWeight != 0 && v.AssistedWeight != 0 {
+			return errors.New("a bodyweight log cannot be both weighted and assisted")
+		}
+		if v.DistanceMeters != 0 {
+			return errors.New("bodyweight logs do not take distance")
+		}
+
+	case model.TypeTimedHold:
+		if v.SetCount == 0 || v.DurationSeconds == 0 {
+			return errors.New("timed hold logs require set_count and duration_seconds")
+		}
+		if v.RepCount != 0 || v.DistanceMeters != 0 || v.AssistedWeight != 0 {
+			return errors.New("timed hold logs do not take reps, distance or assisted weight")
+		}
+
+	case model.TypeDistanceCardio:
+		if v.DistanceMeters == 0 || v.DurationSeconds == 0 {
+			return errors.New("distance cardio logs require distance_meters and duration_seconds")
+		}
+		if v.RepCount != 0 || v.Weight != 0 || v.AddedWeight != 0 || v.AssistedWeight != 0 {
+			return errors.New("distance cardio logs do not take reps or weights")
+		}
+
+	case model.TypeIntervals:
+		if v.SetCount == 0 || v.DurationSeconds == 0 {
+			return errors.New("interval logs require set_count (intervals) and duration_seconds (work per interval)")
+		}
+		if v.RepCount != 0 || v.Weight != 0 || v.AddedWeight != 0 || v.AssistedWeight != 0 {
+			return errors.New("interval logs do not take reps or weights")
+		}
+
+	default:
+		return errors.New("unknown exercise type " + exerciseType)
+	}
+
+	return nil
+}
+
+// PaceSecondsPerKm derives the pace of a distance effort, 0 when it cannot be computed
+func PaceSecondsPerKm(durationSeconds, distanceMeters int) int {
+	if distanceMeters <= 0 {
+		return 0
+	}
+	return durationSeconds * 1000 / distanceMeters
+}
+
+// MaxNotesLength is the longest free-text note accepted on a log
+const MaxNotesLength = 2000
+
+// tempoPattern matches tempo notation in seconds for eccentric, bottom pause, concentric
+// and top pause, X meaning explosive, e.g. 3-1-1-0 or 2-0-X-1
+var tempoPattern = regexp.MustCompile(`^([0-9]{1,2}|X)-([0-9]{1,2}|X)-([0-9]{1,2}|X)-([0-9]{1,2}|X)$`)
+
+// LogMetadata is the context recorded with a log on how it went
+type LogMetadata struct {
+	Notes           string
+	Tempo           string
+	RestSeconds     int
+	PerceivedEffort *float64
+}
+
+// ValidateLogMetadata checks the optional notes, tempo, rest and effort of a log
+func ValidateLogMetadata(m LogMetadata) error {
+	if utf8.RuneCountInString(m.Notes) > MaxNotesLength {
+		return fmt.Errorf("notes cannot be longer than %d characters", MaxNotesLength)
+	}
+	if m.Tempo != "" && !tempoPattern.MatchString(m.Tempo) {
+		return errors.New("tempo must look like 3-1-1-0, seconds or X for each phase")
+	}
+	if m.RestSeconds < 0 {
+		return errors.New("rest_seconds cannot be negative")
+	}
+	if m.PerceivedEffort != nil && (*m.PerceivedEffort < 1 || *m.PerceivedEffort > 10) {
+		return errors.New("perceived_effort must be between 1 and 10")
+	}
+	return nil
+}
+
+// NormalizeTempo uppercases and trims tempo notation so 2-0-x-1 is stored as 2-0-X-1
+func NormalizeTempo(tempo string) string {
+	return strings.ToUpper(strings.TrimSpace(tempo))
+}
@@ -0,0 +1,116 @@
+package helper
+
+import (
+	"fmt"
+	"math"
+)
+
+// Supported progression strategies
+const (
+	StrategyLinear = "linear"
+	StrategyDouble = "double"
+	StrategyRPE    = "rpe"
+)
+
+// ProgressionSet is one entry of an exercise history, most recent first
+type ProgressionSet struct {
+	SetCount int
+	RepCount int
+	Weight   float64
+	RPE      *float64 // effort logged for the session, nil when none was logged
+}
+
+// ProgressionConfig holds the tunables of the progression strategies
+type ProgressionConfig struct {
+	Strategy  string
+	Increment float64 // weight step added on progression
+	RepMin    int     // double progression lower bound
+	RepMax    int     // double progression upper bound
+	TargetRPE float64 // rpe strategy target effort
+}
+
+// ProgressionTarget is the suggested next session
+type ProgressionTarget struct {
+	SetCount  int
+	RepCount  int
+	Weight    float64
+	Reasoning string
+}
+
+// roundToIncrement rounds weight to the nearest multiple of increment
+func roundToIncrement(weight float64, increment float64) float64 {
+	return math.Round(weight/increment) * increment
+}
+
+// NextTarget computes the next sets/reps/weight from history (most recent first)
+func NextTarget(history []ProgressionSet, cfg ProgressionConfig) (ProgressionTarget, error) {
+	if len(history) == 0 {
+		return ProgressionTarget{}, fmt.Errorf("no history to progress from")
+	}
+	if cfg.Increment <= 0 {
+		return ProgressionTarget{}, fmt.Errorf("increment must be greater than 0")
+	}
+	last := history[0]
+
+	switch cfg.Strategy {
+	case StrategyLinear:
+		// Two sessions in a row at the same weight with fewer reps means a stall: deload 10%
+		if len(history) > 1 && history[1].Weight == last.Weight && last.RepCount < history[1].RepCount {
+			return ProgressionTarget{
+				SetCount:  last.SetCount,
+				RepCount:  history[1].RepCount,
+				Weight:    roundToIncrement(last.Weight*0.9, cfg.Increment),
+				Reasoning: fmt.Sprintf("Reps dropped from %d to %d at %g, deloading by 10%%", history[1].RepCount, last.RepCount, last.Weight),
+			}, nil
+		}
+		return ProgressionTarget{
+			SetCount:  last.SetCount,
+			RepCount:  last.RepCount,
+			Weight:    last.Weight + cfg.Increment,
+			Reasoning: fmt.Sprintf("Last session completed %dx%d at %g, adding %g", last.SetCount, last.RepCount, last.Weight, cfg.Increment),
+		}, nil
+
+	case StrategyDouble:
+		if cfg.RepMin <= 0 || cfg.RepMax < cfg.RepMin {
+			return ProgressionTarget{}, fmt.Errorf("invalid rep range %d-%d", cfg.RepMin, cfg.RepMax)
+		}
+		if last.RepCount >= cfg.RepMax {
+			return ProgressionTarget{
+				SetCount:  last.SetCount,
+				RepCount:  cfg.RepMin,
+				Weight:    last.Weight + cfg.Increment,
+				Reasoning: fmt.Sprintf("Reached the top of the %d-%d range, adding %g and dropping back to %d reps", cfg.RepMin, cfg.RepMax, cfg.Increment, cfg.RepMin),
+			}, nil
+		}
+		reps := last.RepCount + 1
+		if reps < cfg.RepMin {
+			reps = cfg.RepMin
+		}
+		return ProgressionTarget{
+			SetCount:  last.SetCount,
+			RepCount:  reps,
+			Weight:    last.Weight,
+			Reasoning: fmt.Sprintf("Below the top of the %d-%d range, keeping %g and aiming for %d reps", cfg.RepMin, cfg.RepMax, last.Weight, reps),
+		}, nil
+
+	case StrategyRPE:
+		if cfg.TargetRPE < 1 || cfg.TargetRPE > 10 {
+			return ProgressionTarget{}, fmt.Errorf("target_rpe must be between 1 and 10")
+		}
+		if last.RPE == nil {
+			return ProgressionTarget{}, fmt.Errorf("the last log has no RPE, log its sets with rpe or a perceived_effort")
+		}
+		lastRPE := *last.RPE
+		// Roughly 3.5% load per RPE point
+		diff := cfg.TargetRPE - lastRPE
+		weight := roundToIncrement(last.Weight*(1+0.035*diff), cfg.Increment)
+		return ProgressionTarget{
+			SetCount:  last.SetCount,
+			RepCount:  last.RepCount,
+			Weight:    weight,
+			Reasoning: fmt.Sprintf("Last session felt like RPE %.1f against a target of %.1f, adjusting load by %.1f%%", lastRPE, cfg.TargetRPE, diff*3.5),
+		}, nil
+	}
+
+	return ProgressionTarget{}, fmt.Errorf("unknown strategy %q", cfg.Strategy)
+}
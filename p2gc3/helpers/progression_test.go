@@ -0,0 +1,127 @@
+package helper
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestNextTarget(t *testing.T) {
+	rpe := func(v float64) *float64 { return &v }
+	linear := ProgressionConfig{Strategy: StrategyLinear, Increment: 2.5}
+	double := ProgressionConfig{Strategy: StrategyDouble, Increment: 2.5, RepMin: 8, RepMax: 12}
+	byRPE := ProgressionConfig{Strategy: StrategyRPE, Increment: 2.5, TargetRPE: 8}
+
+	tests := []struct {
+		name    string
+		history []ProgressionSet
+		cfg     ProgressionConfig
+		want    ProgressionTarget
+		wantErr string
+	}{
+		{
+			name:    "linear adds the increment",
+			history: []ProgressionSet{{SetCount: 3, RepCount: 5, Weight: 100}},
+			cfg:     linear,
+			want:    ProgressionTarget{SetCount: 3, RepCount: 5, Weight: 102.5},
+		},
+		{
+			name:    "linear deloads on a stall",
+			history: []ProgressionSet{{SetCount: 3, RepCount: 4, Weight: 100}, {SetCount: 3, RepCount: 5, Weight: 100}},
+			cfg:     linear,
+			want:    ProgressionTarget{SetCount: 3, RepCount: 5, Weight: 90},
+		},
+		{
+			name:    "double adds reps below the top of the range",
+			history: []ProgressionSet{{SetCount: 3, RepCount: 9, Weight: 50}},
+			cfg:     double,
+			want:    ProgressionTarget{SetCount: 3, RepCount: 10, Weight: 50},
+		},
+		{
+			name:    "double starts at the bottom of the range",
+			history: []ProgressionSet{{SetCount: 3, RepCount: 5, Weight: 50}},
+			cfg:     double,
+			want:    ProgressionTarget{SetCount: 3, RepCount: 8, Weight: 50},
+		},
+		{
+			name:    "double adds weight at the top of the range",
+			history: []ProgressionSet{{SetCount: 3, RepCount: 12, Weight: 50}},
+			cfg:     double,
+			want:    ProgressionTarget{SetCount: 3, RepCount: 8, Weight: 52.5},
+		},
+		{
+			name:    "rpe below target adds load",
+			history: []ProgressionSet{{SetCount: 3, RepCount: 5, Weight: 100, RPE: rpe(7)}},
+			cfg:     byRPE,
+			want:    ProgressionTarget{SetCount: 3, RepCount: 5, Weight: 102.5},
+		},
+		{
+			name:    "rpe above target removes load",
+			history: []ProgressionSet{{SetCount: 3, RepCount: 5, Weight: 100, RPE: rpe(10)}},
+			cfg:     byRPE,
+			want:    ProgressionTarget{SetCount: 3, RepCount: 5, Weight: 92.5},
+		},
+		{
+			name:    "rpe needs a logged rpe",
+			history: []ProgressionSet{{SetCount: 3, RepCount: 5, Weight: 100}},
+			cfg:     byRPE,
+			wantErr: "no RPE",
+		},
+		{
+			name:    "target rpe below 1",
+			history: []ProgressionSet{{SetCount: 3, RepCount: 5, Weight: 100, RPE: rpe(8)}},
+			cfg:     ProgressionConfig{Strategy: StrategyRPE, Increment: 2.5, TargetRPE: 0.5},
+			wantErr: "between 1 and 10",
+		},
+		{
+			name:    "target rpe above 10",
+			history: []ProgressionSet{{SetCount: 3, RepCount: 5, Weight: 100, RPE: rpe(8)}},
+			cfg:     ProgressionConfig{Strategy: StrategyRPE, Increment: 2.5, TargetRPE: 11},
+			wantErr: "between 1 and 10",
+		},
+		{
+			name:    "increment must be positive",
+			history: []ProgressionSet{{SetCount: 3, RepCount: 5, Weight: 100}},
+			cfg:     ProgressionConfig{Strategy: StrategyLinear},
+			wantErr: "increment",
+		},
+		{
+			name:    "invalid rep range",
+			history: []ProgressionSet{{SetCount: 3, RepCount: 5, Weight: 100}},
+			cfg:     ProgressionConfig{Strategy: StrategyDouble, Increment: 2.5, RepMin: 12, RepMax: 8},
+			wantErr: "rep range",
+		},
+		{
+			name:    "unknown strategy",
+			history: []ProgressionSet{{SetCount: 3, RepCount: 5, Weight: 100}},
+			cfg:     ProgressionConfig{Strategy: "wave", Increment: 2.5},
+			wantErr: "unknown strategy",
+		},
+		{
+			name:    "no history",
+			cfg:     linear,
+			wantErr: "no history",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NextTarget(tt.history, tt.cfg)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("NextTarget error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("NextTarget: %v", err)
+			}
+			if got.SetCount != tt.want.SetCount || got.RepCount != tt.want.RepCount || got.Weight != tt.want.Weight {
+				t.Errorf("NextTarget = %dx%d at %v, want %dx%d at %v",
+					got.SetCount, got.RepCount, got.Weight, tt.want.SetCount, tt.want.RepCount, tt.want.Weight)
+			}
+			if got.Reasoning == "" {
+				t.Error("NextTarget gave no reasoning")
+			}
+		})
+	}
+}
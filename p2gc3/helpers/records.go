@@ -0,0 +1,164 @@
+package helper
+
+import (
+	"math"
+	"p2gc3/model"
+	"strconv"
+)
+
+// EpleyOneRepMax estimates a one-rep max as weight x (1 + reps / 30)
+func EpleyOneRepMax(weight float64, reps int) float64 {
+	if reps <= 1 {
+		return weight
+	}
+	return weight * (1 + float64(reps)/30)
+}
+
+// BrzyckiOneRepMax estimates a one-rep max as weight x 36 / (37 - reps)
+func BrzyckiOneRepMax(weight float64, reps int) float64 {
+	if reps <= 1 {
+		return weight
+	}
+	if reps >= 37 {
+		// The formula diverges at 37 reps
+		return EpleyOneRepMax(weight, reps)
+	}
+	return weight * 36 / (37 - float64(reps))
+}
+
+// EstimateOneRepMax uses Brzycki up to 10 reps, where it is the more accurate of the two,
+// and Epley above, where Brzycki grows too fast
+func EstimateOneRepMax(weight float64, reps int) float64 {
+	if reps <= 10 {
+		return roundRecord(BrzyckiOneRepMax(weight, reps))
+	}
+	return roundRecord(EpleyOneRepMax(weight, reps))
+}
+
+// RecordCandidate is the best value of one record type reached by a log
+type RecordCandidate struct {
+	RecordType string
+	Value      float64 // kg, reps for most_reps
+	Weight     float64 // kg of the set
+	RepCount   int
+}
+
+// RecordKey identifies the record a candidate competes with: the record type, and the weight
+// for most_reps since reps are only compared at the same weight
+func RecordKey(recordType string, weight float64) string {
+	if recordType != model.RecordMostReps {
+		return recordType
+	}
+	return recordType + "@" + strconv.FormatFloat(math.Round(weight*1000)/1000, 'f', -1, 64)
+}
+
+// RecordCandidates returns the best value of every record type reached by the sets of one log.
+// Warm-ups are left out; weights are the load lifted, the added weight for bodyweight exercises.
+func RecordCandidates(sets []SetValues) []RecordCandidate {
+	var heaviest, oneRepMax *RecordCandidate
+	mostReps := map[string]*RecordCandidate{}
+	var order []string
+	volume := RecordCandidate{RecordType: model.RecordBestVolume}
+
+	for _, s := range sets {
+		if s.SetType == model.SetTypeWarmup || s.RepCount <= 0 {
+			continue
+		}
+
+		key := RecordKey(model.RecordMostReps, s.Weight)
+		if best, ok := mostReps[key]; !ok {
+			mostReps[key] = &RecordCandidate{RecordType: model.RecordMostReps, Value: float64(s.RepCount), Weight: s.Weight, RepCount: s.RepCount}
+			order = append(order, key)
+		} else if s.RepCount > best.RepCount {
+			best.Value, best.RepCount = float64(s.RepCount), s.RepCount
+		}
+
+		// Weight based records need a load, they mean nothing for unweighted bodyweight sets
+		if s.Weight <= 0 {
+			continue
+		}
+		if heaviest == nil || s.Weight > heaviest.Weight || (s.Weight == heaviest.Weight && s.RepCount > heaviest.RepCount) {
+			heaviest = &RecordCandidate{RecordType: model.RecordHeaviestWeight, Value: s.Weight, Weight: s.Weight, RepCount: s.RepCount}
+		}
+		if e := EstimateOneRepMax(s.Weight, s.RepCount); oneRepMax == nil || e > oneRepMax.Value {
+			oneRepMax = &RecordCandidate{RecordType: model.RecordEstimated1RM, Value: e, Weight: s.Weight, RepCount: s.RepCount}
+		}
+		volume.Value += s.Weight * float64(s.RepCount)
+		volume.RepCount += s.RepCount
+	}
+
+	var candidates []RecordCandidate
+	if heaviest != nil {
+		candidates = append(candidates, *heaviest)
+	}
+	for _, key := range order {
+		candidates = append(candidates, *mostReps[key])
+	}
+	if oneRepMax != nil {
+		candidates = append(candidates, *oneRepMax)
+	}
+	if volume.Value > 0 {
+		volume.Value = roundRecord(volume.Value)
+		candidates = append(candidates, volume)
+	}
+	return candidates
+}
+
+// roundRecord rounds a record value to the stored precision
+func roundRecord(value float64) float64 {
+	return math.Round(value*1000) / 1000
+}
+
+// RecordSets returns the sets personal records are evaluated on, weights in kg. Aggregate-only
+// logs count as set_count identical sets. Only strength and bodyweight exercises have records.
+func RecordSets(exerciseType string, log model.ExerciseLog) []SetValues {
+	if exerciseType != model.TypeStrength && exerciseType != model.TypeBodyweight {
+		return nil
+	}
+
+	var sets []SetValues
+	for _, s := range log.Sets {
+		sets = append(sets, SetValues{SetType: s.SetType, RepCount: s.RepCount, Weight: s.Weight})
+	}
+	if len(sets) > 0 {
+		return sets
+	}
+
+	weight := log.Weight
+	if exerciseType == model.TypeBodyweight {
+		weight = log.AddedWeight
+	}
+	for i := 0; i < log.SetCount; i++ {
+		sets = append(sets, SetValues{SetType: model.SetTypeWorking, RepCount: log.RepCount, Weight: weight})
+	}
+	return sets
+}
+
+// BeatenRecords returns the records log sets with the candidates that beat best, the best value
+// per RecordKey so far, and raises best to them
+func BeatenRecords(log model.ExerciseLog, candidates []RecordCandidate, best map[string]float64) []model.PersonalRecord {
+	var records []model.PersonalRecord
+	for _, candidate := range candidates {
+		record := model.PersonalRecord{
+			UserID:        log.UserID,
+			DefinitionID:  log.DefinitionID,
+			ExerciseID:    log.ExerciseID,
+			ExerciseLogID: log.ID,
+			RecordType:    candidate.RecordType,
+			Value:         candidate.Value,
+			Weight:        candidate.Weight,
+			RepCount:      candidate.RepCount,
+			AchievedAt:    log.CreatedAt,
+		}
+		key := RecordKey(candidate.RecordType, candidate.Weight)
+		if previous, ok := best[key]; ok {
+			if candidate.Value <= previous {
+				continue
+			}
+			record.PreviousValue = &previous
+		}
+		best[key] = candidate.Value
+		records = append(records, record)
+	}
+	return records
+}
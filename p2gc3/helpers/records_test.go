@@ -0,0 +1,226 @@
+package helper
+
+import (
+	"math"
+	"p2gc3/model"
+	"testing"
+	"time"
+)
+
+func TestOneRepMaxFormulas(t *testing.T) {
+	tests := []struct {
+		name     string
+		weight   float64
+		reps     int
+		epley    float64
+		brzycki  float64
+		estimate float64
+	}{
+		{"single", 100, 1, 100, 100, 100},
+		{"five reps", 100, 5, 116.667, 112.5, 112.5},
+		// Both formulas meet at 10 reps, where the estimate switches from Brzycki to Epley
+		{"crossover", 100, 10, 133.333, 133.333, 133.333},
+		{"past crossover", 100, 11, 136.667, 138.462, 136.667},
+		{"last Brzycki rep", 100, 36, 220, 3600, 220},
+		// Brzycki divides by 37 - reps, it falls back to Epley from there
+		{"Brzycki diverges", 100, 37, 223.333, 223.333, 223.333},
+		{"past divergence", 100, 40, 233.333, 233.333, 233.333},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := EpleyOneRepMax(tt.weight, tt.reps); math.Abs(got-tt.epley) > 0.001 {
+				t.Errorf("EpleyOneRepMax = %v, want %v", got, tt.epley)
+			}
+			if got := BrzyckiOneRepMax(tt.weight, tt.reps); math.Abs(got-tt.brzycki) > 0.001 {
+				t.Errorf("BrzyckiOneRepMax = %v, want %v", got, tt.brzycki)
+			}
+			if got := EstimateOneRepMax(tt.weight, tt.reps); got != tt.estimate {
+				t.Errorf("EstimateOneRepMax = %v, want %v", got, tt.estimate)
+			}
+		})
+	}
+}
+
+func TestRecordKey(t *testing.T) {
+	tests := []struct {
+		name    string
+		a, b    string
+		wa, wb  float64
+		sameKey bool
+	}{
+		{"most reps at the same weight", model.RecordMostReps, model.RecordMostReps, 100, 100, true},
+		{"most reps below stored precision", model.RecordMostReps, model.RecordMostReps, 100, 100.0004, true},
+		{"most reps at another weight", model.RecordMostReps, model.RecordMostReps, 100, 100.01, false},
+		{"heaviest ignores the weight", model.RecordHeaviestWeight, model.RecordHeaviestWeight, 100, 120, true},
+		{"types never share a key", model.RecordHeaviestWeight, model.RecordEstimated1RM, 100, 100, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := RecordKey(tt.a, tt.wa) == RecordKey(tt.b, tt.wb); got != tt.sameKey {
+				t.Errorf("RecordKey(%s, %v) == RecordKey(%s, %v) is %v, want %v", tt.a, tt.wa, tt.b, tt.wb, got, tt.sameKey)
+			}
+		})
+	}
+}
+
+func TestRecordCandidates(t *testing.T) {
+	tests := []struct {
+		name string
+		sets []SetValues
+		want []RecordCandidate
+	}{
+		{
+			name: "warm-ups are left out",
+			sets: []SetValues{
+				{SetType: model.SetTypeWarmup, RepCount: 10, Weight: 60},
+				{SetType: model.SetTypeWorking, RepCount: 5, Weight: 100},
+				{SetType: model.SetTypeWorking, RepCount: 6, Weight: 100},
+				{SetType: model.SetTypeDrop, RepCount: 10, Weight: 80},
+			},
+			want: []RecordCandidate{
+				{RecordType: model.RecordHeaviestWeight, Value: 100, Weight: 100, RepCount: 6},
+				{RecordType: model.RecordMostReps, Value: 6, Weight: 100, RepCount: 6},
+				{RecordType: model.RecordMostReps, Value: 10, Weight: 80, RepCount: 10},
+				{RecordType: model.RecordEstimated1RM, Value: 116.129, Weight: 100, RepCount: 6},
+				{RecordType: model.RecordBestVolume, Value: 1900, RepCount: 21},
+			},
+		},
+		{
+			name: "unweighted sets only have most reps",
+			sets: []SetValues{
+				{SetType: model.SetTypeWorking, RepCount: 12},
+				{SetType: model.SetTypeFailure, RepCount: 9},
+			},
+			want: []RecordCandidate{
+				{RecordType: model.RecordMostReps, Value: 12, RepCount: 12},
+			},
+		},
+		{
+			name: "only warm-ups",
+			sets: []SetValues{{SetType: model.SetTypeWarmup, RepCount: 10, Weight: 60}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := RecordCandidates(tt.sets)
+			if len(got) != len(tt.want) {
+				t.Fatalf("RecordCandidates = %+v, want %+v", got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("candidate %d = %+v, want %+v", i, got[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestRecordSets(t *testing.T) {
+	tests := []struct {
+		name         string
+		exerciseType string
+		log          model.ExerciseLog
+		want         []SetValues
+	}{
+		{
+			name:         "logged sets",
+			exerciseType: model.TypeStrength,
+			log: model.ExerciseLog{SetCount: 1, RepCount: 5, Weight: 100, Sets: []model.LogSet{
+				{SetType: model.SetTypeWarmup, RepCount: 8, Weight: 60},
+				{SetType: model.SetTypeWorking, RepCount: 5, Weight: 100},
+			}},
+			want: []SetValues{
+				{SetType: model.SetTypeWarmup, RepCount: 8, Weight: 60},
+				{SetType: model.SetTypeWorking, RepCount: 5, Weight: 100},
+			},
+		},
+		{
+			name:         "aggregate-only strength log",
+			exerciseType: model.TypeStrength,
+			log:          model.ExerciseLog{SetCount: 2, RepCount: 5, Weight: 100},
+			want: []SetValues{
+				{SetType: model.SetTypeWorking, RepCount: 5, Weight: 100},
+				{SetType: model.SetTypeWorking, RepCount: 5, Weight: 100},
+			},
+		},
+		{
+			name:         "aggregate-only bodyweight log uses the added weight",
+			exerciseType: model.TypeBodyweight,
+			log:          model.ExerciseLog{SetCount: 1, RepCount: 8, AddedWeight: 10},
+			want:         []SetValues{{SetType: model.SetTypeWorking, RepCount: 8, Weight: 10}},
+		},
+		{
+			name:         "no records for other types",
+			exerciseType: model.TypeIntervals,
+			log:          model.ExerciseLog{SetCount: 1, RepCount: 1, Weight: 100},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := RecordSets(tt.exerciseType, tt.log)
+			if len(got) != len(tt.want) {
+				t.Fatalf("RecordSets = %+v, want %+v", got, tt.want)
+			}
+			for i := range got {
+				if got[i].SetType != tt.want[i].SetType || got[i].RepCount != tt.want[i].RepCount || got[i].Weight != tt.want[i].Weight {
+					t.Errorf("set %d = %+v, want %+v", i, got[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestBeatenRecordsReplaysHistory(t *testing.T) {
+	day := time.Date(2026, 1, 5, 10, 0, 0, 0, time.UTC)
+	logs := []model.ExerciseLog{
+		{ID: 1, CreatedAt: day, Sets: []model.LogSet{{SetType: model.SetTypeWorking, RepCount: 5, Weight: 100}}},
+		{ID: 2, CreatedAt: day.AddDate(0, 0, 2), Sets: []model.LogSet{{SetType: model.SetTypeWorking, RepCount: 3, Weight: 100}}},
+		{ID: 3, CreatedAt: day.AddDate(0, 0, 4), Sets: []model.LogSet{{SetType: model.SetTypeWorking, RepCount: 5, Weight: 105}}},
+	}
+	// log ID -> record key -> previous value, nil for a first record
+	want := map[uint]map[string]*float64{
+		1: {
+			RecordKey(model.RecordHeaviestWeight, 100): nil,
+			RecordKey(model.RecordMostReps, 100):       nil,
+			RecordKey(model.RecordEstimated1RM, 100):   nil,
+			RecordKey(model.RecordBestVolume, 0):       nil,
+		},
+		2: {},
+		3: {
+			RecordKey(model.RecordHeaviestWeight, 105): ptr(100.0),
+			RecordKey(model.RecordMostReps, 105):       nil,
+			RecordKey(model.RecordEstimated1RM, 105):   ptr(112.5),
+			RecordKey(model.RecordBestVolume, 0):       ptr(500.0),
+		},
+	}
+
+	best := map[string]float64{}
+	for _, log := range logs {
+		records := BeatenRecords(log, RecordCandidates(RecordSets(model.TypeStrength, log)), best)
+		if len(records) != len(want[log.ID]) {
+			t.Fatalf("log %d set %d records, want %d: %+v", log.ID, len(records), len(want[log.ID]), records)
+		}
+		for _, r := range records {
+			key := RecordKey(r.RecordType, r.Weight)
+			previous, ok := want[log.ID][key]
+			if !ok {
+				t.Errorf("log %d set unexpected record %s", log.ID, key)
+				continue
+			}
+			if r.ExerciseLogID != log.ID || !r.AchievedAt.Equal(log.CreatedAt) {
+				t.Errorf("record %s points at log %d achieved %v, want log %d achieved %v", key, r.ExerciseLogID, r.AchievedAt, log.ID, log.CreatedAt)
+			}
+			if (previous == nil) != (r.PreviousValue == nil) || (previous != nil && *previous != *r.PreviousValue) {
+				t.Errorf("record %s of log %d previous = %v, want %v", key, log.ID, r.PreviousValue, previous)
+			}
+		}
+	}
+}
+
+func ptr(v float64) *float64 {
+	return &v
+}
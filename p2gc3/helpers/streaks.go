@@ -0,0 +1,108 @@
+package helper
+
+import (
+	"sort"
+	"time"
+)
+
+// DayNumber numbers the calendar day of t in its own location, consecutive days have consecutive numbers
+func DayNumber(t time.Time) int {
+	return int(time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC).Unix() / 86400)
+}
+
+// DayFromNumber returns the date of a DayNumber, at midnight UTC
+func DayFromNumber(day int) time.Time {
+	return time.Unix(int64(day)*86400, 0).UTC()
+}
+
+// weekNumber numbers the Monday-based week of a DayNumber, day 0 is a Thursday
+func weekNumber(day int) int {
+	return (day + 3) / 7
+}
+
+// StreakConfig sets what keeps a streak alive
+type StreakConfig struct {
+	// RestAllowance is how many unplanned days off in a row keep the daily streak going
+	RestAllowance int
+	// PlannedRest reports the rest days of the training schedule, they never break a streak
+	PlannedRest func(day int) bool
+	// WeeklyTarget is how many training days make a week count for the weekly streak
+	WeeklyTarget int
+}
+
+// Streaks holds the current and longest streaks; daily streaks count training days, weekly ones weeks
+type Streaks struct {
+	CurrentDays  int
+	LongestDays  int
+	CurrentWeeks int
+	LongestWeeks int
+}
+
+// ComputeStreaks computes the streaks of the given training days up to today. Today and the
+// current week are still in progress, so not having trained yet does not end a streak.
+func ComputeStreaks(trainingDays []int, today int, cfg StreakConfig) Streaks {
+	days := append([]int(nil), trainingDays...)
+	sort.Ints(days)
+
+	var s Streaks
+	if len(days) == 0 {
+		return s
+	}
+
+	// missed counts the unplanned days off strictly between two days
+	missed := func(from, to int) int {
+		n := 0
+		for d := from + 1; d < to; d++ {
+			if cfg.PlannedRest == nil || !cfg.PlannedRest(d) {
+				n++
+			}
+		}
+		return n
+	}
+
+	run := 0
+	for i, d := range days {
+		if i > 0 && d == days[i-1] {
+			continue
+		}
+		if i == 0 || missed(days[i-1], d) > cfg.RestAllowance {
+			run = 0
+		}
+		run++
+		if run > s.LongestDays {
+			s.LongestDays = run
+		}
+	}
+	if last := days[len(days)-1]; last <= today && missed(last, today) <= cfg.RestAllowance {
+		s.CurrentDays = run
+	}
+
+	target := cfg.WeeklyTarget
+	if target < 1 {
+		target = 1
+	}
+	perWeek := map[int]int{}
+	for i, d := range days {
+		if i == 0 || d != days[i-1] {
+			perWeek[weekNumber(d)]++
+		}
+	}
+
+	first, current := weekNumber(days[0]), weekNumber(today)
+	run = 0
+	for w := first; w <= current; w++ {
+		switch {
+		case perWeek[w] >= target:
+			run++
+		case w == current:
+			// The week is not over yet, the streak runs up to last week
+		default:
+			run = 0
+		}
+		if run > s.LongestWeeks {
+			s.LongestWeeks = run
+		}
+	}
+	s.CurrentWeeks = run
+	return s
+}
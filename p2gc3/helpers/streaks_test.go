@@ -0,0 +1,132 @@
+package helper
+
+import (
+	"testing"
+	"time"
+)
+
+func TestDayAndWeekNumbers(t *testing.T) {
+	tests := []struct {
+		name string
+		t    time.Time
+		day  int
+		week int
+	}{
+		{"epoch is a Thursday", time.Date(1970, 1, 1, 0, 0, 0, 0, time.UTC), 0, 0},
+		{"Sunday ends the first week", time.Date(1970, 1, 4, 23, 59, 0, 0, time.UTC), 3, 0},
+		{"Monday starts the next week", time.Date(1970, 1, 5, 0, 0, 0, 0, time.UTC), 4, 1},
+		{"local day, not the UTC one", time.Date(1970, 1, 5, 0, 30, 0, 0, time.FixedZone("UTC+2", 2*3600)), 4, 1},
+		{"late evening west of UTC", time.Date(1970, 1, 4, 23, 0, 0, 0, time.FixedZone("UTC-5", -5*3600)), 3, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			day := DayNumber(tt.t)
+			if day != tt.day {
+				t.Fatalf("DayNumber = %d, want %d", day, tt.day)
+			}
+			if week := weekNumber(day); week != tt.week {
+				t.Errorf("weekNumber = %d, want %d", week, tt.week)
+			}
+			if got := DayFromNumber(day); DayNumber(got) != day || got.Hour() != 0 {
+				t.Errorf("DayFromNumber(%d) = %v, not midnight of the same day", day, got)
+			}
+		})
+	}
+}
+
+func TestComputeStreaks(t *testing.T) {
+	// Day 4 is a Monday, days 4-10 are one week
+	tests := []struct {
+		name  string
+		days  []int
+		today int
+		cfg   StreakConfig
+		want  Streaks
+	}{
+		{
+			name: "no training",
+			want: Streaks{},
+		},
+		{
+			name:  "consecutive days",
+			days:  []int{4, 5, 6},
+			today: 6,
+			want:  Streaks{CurrentDays: 3, LongestDays: 3, CurrentWeeks: 1, LongestWeeks: 1},
+		},
+		{
+			name:  "today is still in progress",
+			days:  []int{4, 5, 6},
+			today: 7,
+			want:  Streaks{CurrentDays: 3, LongestDays: 3, CurrentWeeks: 1, LongestWeeks: 1},
+		},
+		{
+			name:  "a day off breaks the streak without allowance",
+			days:  []int{4, 6},
+			today: 6,
+			want:  Streaks{CurrentDays: 1, LongestDays: 1, CurrentWeeks: 1, LongestWeeks: 1},
+		},
+		{
+			name:  "a day off within the rest allowance",
+			days:  []int{4, 6},
+			today: 6,
+			cfg:   StreakConfig{RestAllowance: 1},
+			want:  Streaks{CurrentDays: 2, LongestDays: 2, CurrentWeeks: 1, LongestWeeks: 1},
+		},
+		{
+			name:  "two days off exceed the allowance",
+			days:  []int{4, 7},
+			today: 7,
+			cfg:   StreakConfig{RestAllowance: 1},
+			want:  Streaks{CurrentDays: 1, LongestDays: 1, CurrentWeeks: 1, LongestWeeks: 1},
+		},
+		{
+			name:  "the allowance runs out since the last training day",
+			days:  []int{4, 5},
+			today: 8,
+			cfg:   StreakConfig{RestAllowance: 1},
+			want:  Streaks{CurrentDays: 0, LongestDays: 2, CurrentWeeks: 1, LongestWeeks: 1},
+		},
+		{
+			name:  "planned rest never breaks a streak",
+			days:  []int{4, 7},
+			today: 9,
+			cfg:   StreakConfig{PlannedRest: func(day int) bool { return day == 5 || day == 6 || day == 8 }},
+			want:  Streaks{CurrentDays: 2, LongestDays: 2, CurrentWeeks: 1, LongestWeeks: 1},
+		},
+		{
+			name:  "duplicate days count once",
+			days:  []int{5, 4, 5, 4},
+			today: 5,
+			want:  Streaks{CurrentDays: 2, LongestDays: 2, CurrentWeeks: 1, LongestWeeks: 1},
+		},
+		{
+			name:  "the current week is not over yet",
+			days:  []int{4, 11, 18},
+			today: 25,
+			cfg:   StreakConfig{RestAllowance: 7},
+			want:  Streaks{CurrentDays: 3, LongestDays: 3, CurrentWeeks: 3, LongestWeeks: 3},
+		},
+		{
+			name:  "a missed week ends the weekly streak",
+			days:  []int{4, 11, 18},
+			today: 32,
+			want:  Streaks{CurrentDays: 0, LongestDays: 1, CurrentWeeks: 0, LongestWeeks: 3},
+		},
+		{
+			name:  "weeks below the weekly target",
+			days:  []int{4, 5, 11, 18, 19},
+			today: 19,
+			cfg:   StreakConfig{RestAllowance: 6, WeeklyTarget: 2},
+			want:  Streaks{CurrentDays: 5, LongestDays: 5, CurrentWeeks: 1, LongestWeeks: 1},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ComputeStreaks(tt.days, tt.today, tt.cfg); got != tt.want {
+				t.Errorf("ComputeStreaks = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,78 @@
+package helper
+
+import "fmt"
+
+// Weights of the substitution score components
+const (
+	scoreSharedPrimary   = 3
+	scoreSharedSecondary = 1
+	scoreSamePattern     = 4
+	scoreSameType        = 2
+)
+
+// SubstituteProfile is what substitution ranking knows about a catalog exercise
+type SubstituteProfile struct {
+	Pattern   string
+	Type      string
+	Primary   []string
+	Secondary []string
+	Equipment []string
+}
+
+func toSet(values []string) map[string]bool {
+	set := map[string]bool{}
+	for _, v := range values {
+		set[v] = true
+	}
+	return set
+}
+
+// ScoreSubstitute rates how well candidate replaces source; 0 means they share nothing useful
+func ScoreSubstitute(source, candidate SubstituteProfile) (int, []string) {
+	score := 0
+	var reasons []string
+
+	// A source primary mover trained by the candidate in any role still counts, less when secondary
+	candPrimary, candSecondary := toSet(candidate.Primary), toSet(candidate.Secondary)
+	for _, m := range source.Primary {
+		if candPrimary[m] {
+			score += scoreSharedPrimary
+			reasons = append(reasons, fmt.Sprintf("trains %s as primary mover", m))
+		} else if candSecondary[m] {
+			score += scoreSharedSecondary
+			reasons = append(reasons, fmt.Sprintf("trains %s as secondary mover", m))
+		}
+	}
+	for _, m := range source.Secondary {
+		if candPrimary[m] || candSecondary[m] {
+			score += scoreSharedSecondary
+		}
+	}
+
+	if score == 0 {
+		return 0, nil
+	}
+
+	if source.Pattern != "" && source.Pattern == candidate.Pattern {
+		score += scoreSamePattern
+		reasons = append(reasons, "same movement pattern ("+source.Pattern+")")
+	}
+	if source.Type == candidate.Type {
+		score += scoreSameType
+	}
+	return score, reasons
+}
+
+// EquipmentAvailable reports whether everything required is in the available set.
+// A nil set means availability is unknown and everything is allowed.
+func EquipmentAvailable(required []string, available map[string]bool) bool {
+	if available == nil {
+		return true
+	}
+	for _, e := range required {
+		if e != "bodyweight" && !available[e] {
+			return false
+		}
+	}
+	return true
+}
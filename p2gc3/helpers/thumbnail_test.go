@@ -0,0 +1,46 @@
+package helper
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/gif"
+	"image/jpeg"
+	"image/png"
+	"strings"
+	"testing"
+)
+
+func TestGenerateThumbnailScalesLongestSide(t *testing.T) {
+	var src bytes.Buffer
+	if err := png.Encode(&src, image.NewRGBA(image.Rect(0, 0, 300, 150))); err != nil {
+		t.Fatal(err)
+	}
+
+	thumb, err := GenerateThumbnail(&src, 100)
+	if err != nil {
+		t.Fatalf("GenerateThumbnail: %v", err)
+	}
+	cfg, err := jpeg.DecodeConfig(bytes.NewReader(thumb))
+	if err != nil {
+		t.Fatalf("thumbnail is not a JPEG: %v", err)
+	}
+	if cfg.Width != 100 || cfg.Height != 50 {
+		t.Errorf("thumbnail is %dx%d, want 100x50", cfg.Width, cfg.Height)
+	}
+}
+
+func TestGenerateThumbnailRejectsHugeCanvas(t *testing.T) {
+	var src bytes.Buffer
+	if err := gif.Encode(&src, image.NewPaletted(image.Rect(0, 0, 1, 1), []color.Color{color.Black}), nil); err != nil {
+		t.Fatal(err)
+	}
+	// The logical screen descriptor follows the 6 byte signature, width and height little endian
+	b := src.Bytes()
+	b[6], b[7], b[8], b[9] = 0xff, 0xff, 0xff, 0xff
+
+	_, err := GenerateThumbnail(bytes.NewReader(b), 100)
+	if err == nil || !strings.Contains(err.Error(), "65535x65535") {
+		t.Fatalf("GenerateThumbnail error = %v, want the pixel limit", err)
+	}
+}
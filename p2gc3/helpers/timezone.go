@@ -0,0 +1,38 @@
+package helper
+
+import (
+	"errors"
+	"time"
+)
+
+// DefaultTimezone is the timezone of users who have not set one
+const DefaultTimezone = "UTC"
+
+// Bounds of log timestamps: client clocks drift a little ahead, and nothing was logged before 1970
+const logTimeFutureTolerance = 5 * time.Minute
+
+var minLogTime = time.Date(1970, 1, 1, 0, 0, 0, 0, time.UTC)
+
+// LoadTimezone loads an IANA timezone name such as Europe/Paris. The server's "Local" zone is
+// rejected since it means something else on every machine.
+func LoadTimezone(name string) (*time.Location, error) {
+	if name == "" || name == "Local" {
+		return nil, errors.New("timezone must be an IANA name such as Europe/Paris")
+	}
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return nil, errors.New("unknown timezone " + name)
+	}
+	return loc, nil
+}
+
+// ValidateLogTime rejects log timestamps in the future or before 1970
+func ValidateLogTime(t, now time.Time) error {
+	if t.After(now.Add(logTimeFutureTolerance)) {
+		return errors.New("created_at cannot be in the future")
+	}
+	if t.Before(minLogTime) {
+		return errors.New("created_at cannot be before 1970")
+	}
+	return nil
+}
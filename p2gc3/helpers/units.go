@@ -0,0 +1,38 @@
+package helper
+
+import "math"
+
+// Supported weight units, weights are stored in kilograms
+const (
+	UnitKg = "kg"
+	UnitLb = "lb"
+)
+
+// kgPerLb is the exact international avoirdupois pound
+const kgPerLb = 0.45359237
+
+// IsWeightUnit reports whether unit is a supported weight unit
+func IsWeightUnit(unit string) bool {
+	return unit == UnitKg || unit == UnitLb
+}
+
+// ToKg converts a weight given in unit to kilograms, rounded to the stored precision (grams)
+func ToKg(value float64, unit string) float64 {
+	if unit == UnitLb {
+		value *= kgPerLb
+	}
+	return math.Round(value*1000) / 1000
+}
+
+// FromKg converts a weight in kilograms to unit, rounded to two decimals for display
+func FromKg(kg float64, unit string) float64 {
+	if unit == UnitLb {
+		kg /= kgPerLb
+	}
+	return RoundWeight(kg)
+}
+
+// RoundWeight rounds a weight to the two decimals returned by the API
+func RoundWeight(value float64) float64 {
+	return math.Round(value*100) / 100
+}
@@ -0,0 +1,25 @@
+package helper
+
+import (
+	"errors"
+	"strings"
+)
+
+var errInvalidUUID = errors.New("must be a UUID like 123e4567-e89b-12d3-a456-426614174000")
+
+// NormalizeUUID checks that s is a UUID in the 8-4-4-4-12 hex form and returns it in lower case
+func NormalizeUUID(s string) (string, error) {
+	if len(s) != 36 {
+		return "", errInvalidUUID
+	}
+	for i, r := range s {
+		if i == 8 || i == 13 || i == 18 || i == 23 {
+			if r != '-' {
+				return "", errInvalidUUID
+			}
+		} else if !strings.ContainsRune("0123456789abcdefABCDEF", r) {
+			return "", errInvalidUUID
+		}
+	}
+	return strings.ToLower(s), nil
+}
@@ -0,0 +1,121 @@
+package helper
+
+import (
+	"archive/zip"
+	"encoding/xml"
+	"io"
+	"strings"
+)
+
+// Static parts of a workbook with a single sheet, see ECMA-376 part 1
+const (
+	xlsxContentTypes = xml.Header + `<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">` +
+		`<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>` +
+		`<Default Extension="xml" ContentType="application/xml"/>` +
+		`<Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/>` +
+		`<Override PartName="/xl/worksheets/sheet1.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/>` +
+		`</Types>`
+
+	xlsxRootRels = xml.Header + `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+		`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="xl/workbook.xml"/>` +
+		`</Relationships>`
+
+	xlsxWorkbook = xml.Header + `<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">` +
+		`<sheets><sheet name="Logs" sheetId="1" r:id="rId1"/></sheets>` +
+		`</workbook>`
+
+	xlsxWorkbookRels = xml.Header + `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+		`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet1.xml"/>` +
+		`</Relationships>`
+
+	xlsxSheetStart = xml.Header + `<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">` +
+		`<sheetViews><sheetView workbookViewId="0"><pane ySplit="1" topLeftCell="A2" state="frozen"/></sheetView></sheetViews>` +
+		`<sheetData>`
+
+	xlsxSheetEnd = `</sheetData></worksheet>`
+)
+
+// xlsxExportWriter streams rows into the single sheet of a workbook. The static parts are written
+// first so the sheet is the last zip entry and can grow row by row.
+type xlsxExportWriter struct {
+	zip   *zip.Writer
+	sheet io.Writer
+	buf   strings.Builder
+}
+
+func newXLSXExportWriter(w io.Writer) (*xlsxExportWriter, error) {
+	xw := &xlsxExportWriter{zip: zip.NewWriter(w)}
+
+	parts := []struct{ name, content string }{
+		{"[Content_Types].xml", xlsxContentTypes},
+		{"_rels/.rels", xlsxRootRels},
+		{"xl/workbook.xml", xlsxWorkbook},
+		{"xl/_rels/workbook.xml.rels", xlsxWorkbookRels},
+	}
+	for _, p := range parts {
+		f, err := xw.zip.Create(p.name)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := io.WriteString(f, p.content); err != nil {
+			return nil, err
+		}
+	}
+
+	sheet, err := xw.zip.Create("xl/worksheets/sheet1.xml")
+	if err != nil {
+		return nil, err
+	}
+	xw.sheet = sheet
+	if _, err := io.WriteString(sheet, xlsxSheetStart); err != nil {
+		return nil, err
+	}
+
+	header := make([]interface{}, len(ExportColumns))
+	for i, c := range ExportColumns {
+		header[i] = c
+	}
+	if err := xw.writeCells(header); err != nil {
+		return nil, err
+	}
+	return xw, nil
+}
+
+// writeCells writes one sheet row, strings as inline strings so no shared string table is needed
+func (xw *xlsxExportWriter) writeCells(values []interface{}) error {
+	xw.buf.Reset()
+	xw.buf.WriteString("<row>")
+	for _, v := range values {
+		switch v := v.(type) {
+		case nil:
+			xw.buf.WriteString("<c/>")
+		case string:
+			xw.buf.WriteString(`<c t="inlineStr"><is><t xml:space="preserve">`)
+			xml.EscapeText(&xw.buf, []byte(v))
+			xw.buf.WriteString("</t></is></c>")
+		default:
+			xw.buf.WriteString("<c><v>")
+			xw.buf.WriteString(formatExportValue(v))
+			xw.buf.WriteString("</v></c>")
+		}
+	}
+	xw.buf.WriteString("</row>")
+
+	_, err := io.WriteString(xw.sheet, xw.buf.String())
+	return err
+}
+
+func (xw *xlsxExportWriter) WriteRow(row ExportRow) error {
+	return xw.writeCells(row.Values())
+}
+
+func (xw *xlsxExportWriter) Flush() error {
+	return xw.zip.Flush()
+}
+
+func (xw *xlsxExportWriter) Close() error {
+	if _, err := io.WriteString(xw.sheet, xlsxSheetEnd); err != nil {
+		return err
+	}
+	return xw.zip.Close()
+}
@@ -16,6 +16,8 @@ import (
 	"p2gc3/routes"
 
 	"net/http"
+	// Embedded timezone database, user timezones must load on hosts without one
+	_ "time/tzdata"
 
 	"github.com/labstack/echo/v4"
 )
@@ -24,13 +26,49 @@ func main() {
 	// setup configs, loading env, and initializing db
 	config.LoadEnv()
 	db := config.DBInit()
+	config.StorageInit()
+
+	// Weights used to be unitless integers, convert them before AutoMigrate compares the columns
+	if err := config.MigrateWeightColumns(db); err != nil {
+		panic("Failed to migrate weight columns: " + err.Error())
+	}
 
 	// Auto migrating into DB
-	err := db.AutoMigrate(&model.User{}, &model.Workout{}, &model.Exercise{}, &model.ExerciseLog{})
+	err := db.AutoMigrate(&model.User{}, &model.Workout{}, &model.ExerciseGroup{},
+		&model.MuscleGroup{}, &model.Equipment{}, &model.ExerciseDefinition{}, &model.Exercise{}, &model.ExerciseMedia{}, &model.ExerciseSwap{}, &model.WorkoutSession{}, &model.ExerciseLog{}, &model.ExerciseLogRevision{}, &model.LogSet{}, &model.PersonalRecord{}, &model.IdempotencyKey{}, &model.SyncEntity{},
+		&model.Program{}, &model.ProgramWeek{}, &model.ProgramDay{}, &model.ProgramEnrollment{})
 	if err != nil {
 		panic("Failed to auto migrate: " + err.Error())
 	}
 
+	if err := config.MigrateSearchIndexes(db); err != nil {
+		panic("Failed to create search indexes: " + err.Error())
+	}
+
+	if err := config.MigrateEnrollmentIndex(db); err != nil {
+		panic("Failed to create program enrollment index: " + err.Error())
+	}
+
+	if err := config.MigrateSyncTriggers(db); err != nil {
+		panic("Failed to install sync triggers: " + err.Error())
+	}
+
+	// Seed the exercise catalog and taxonomy, and link existing exercises to it
+	if err := config.SeedTaxonomy(db); err != nil {
+		panic("Failed to seed exercise taxonomy: " + err.Error())
+	}
+	if err := config.SeedExerciseCatalog(db); err != nil {
+		panic("Failed to seed exercise catalog: " + err.Error())
+	}
+	if err := config.MigrateExerciseCatalog(db); err != nil {
+		panic("Failed to migrate exercises to catalog: " + err.Error())
+	}
+
+	// Build the personal records of logs stored before records were tracked
+	if err := config.BackfillPersonalRecords(db); err != nil {
+		panic("Failed to backfill personal records: " + err.Error())
+	}
+
 	// initialize echo
 	e := echo.New()
 
@@ -0,0 +1,17 @@
+package middleware
+
+import (
+	"github.com/labstack/echo/v4"
+)
+
+// Deprecated flags responses of a legacy route and points clients to its successor
+func Deprecated(successor string) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			MakeLogEntry(c).Warn("deprecated route called, use " + successor)
+			c.Response().Header().Set("Deprecation", "true")
+			c.Response().Header().Set("Link", "<"+successor+">; rel=\"successor-version\"")
+			return next(c)
+		}
+	}
+}
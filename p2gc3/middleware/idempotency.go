@@ -0,0 +1,167 @@
+package middleware
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"p2gc3/config"
+	"p2gc3/dto"
+	helper "p2gc3/helpers"
+	"p2gc3/model"
+	"strconv"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"gorm.io/gorm/clause"
+)
+
+const (
+	// IdempotencyHeader is the request header clients set to make a write safe to retry
+	IdempotencyHeader = "Idempotency-Key"
+
+	idempotencyKeyMaxLength = 255
+
+	// idempotencyRetryAfter is the Retry-After, in seconds, sent while the original request is in progress
+	idempotencyRetryAfter = 1
+)
+
+// responseRecorder copies everything written to the client so it can be replayed
+type responseRecorder struct {
+	http.ResponseWriter
+	body bytes.Buffer
+}
+
+func (r *responseRecorder) Write(b []byte) (int, error) {
+	r.body.Write(b)
+	return r.ResponseWriter.Write(b)
+}
+
+// requestFingerprint identifies a request by method, path and body
+func requestFingerprint(req *http.Request, body []byte) string {
+	h := sha256.New()
+	h.Write([]byte(req.Method + " " + req.URL.Path + "\n"))
+	h.Write(body)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// Idempotency makes a write route safe to retry. A request with an Idempotency-Key header is
+// run once per user and key; retries within ttl get the stored response back, marked with an
+// Idempotent-Replayed header. Reusing a key for a different request is rejected, as are retries
+// while the original is still running. Server errors are not stored so they can be retried.
+// Requests without the header are left alone. It must run after JWTMiddleware.
+func Idempotency(ttl time.Duration) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			key := c.Request().Header.Get(IdempotencyHeader)
+			if key == "" {
+				return next(c)
+			}
+			if len(key) > idempotencyKeyMaxLength {
+				return echo.NewHTTPError(http.StatusBadRequest, dto.ErrorResponse{
+					Message: "Invalid Idempotency-Key",
+					Details: "the key must be at most " + strconv.Itoa(idempotencyKeyMaxLength) + " characters",
+				})
+			}
+
+			userID, err := helper.ExtractUserID(c)
+			if err != nil {
+				return next(c)
+			}
+
+			body, err := io.ReadAll(c.Request().Body)
+			if err != nil {
+				return echo.NewHTTPError(http.StatusBadRequest, dto.ErrorResponse{
+					Message: "Invalid input: failed to read request body",
+					Details: err.Error(),
+				})
+			}
+			c.Request().Body = io.NopCloser(bytes.NewReader(body))
+			fingerprint := requestFingerprint(c.Request(), body)
+
+			now := time.Now()
+			if err := config.DB.Where("user_id = ? AND expires_at < ?", userID, now).
+				Delete(&model.IdempotencyKey{}).Error; err != nil {
+				return echo.NewHTTPError(http.StatusInternalServerError, dto.ErrorResponse{
+					Message: "Failed to check Idempotency-Key",
+					Details: err.Error(),
+				})
+			}
+
+			// Claim the key, only one request can insert it
+			record := model.IdempotencyKey{
+				UserID:      userID,
+				Key:         key,
+				Fingerprint: fingerprint,
+				CreatedAt:   now,
+				ExpiresAt:   now.Add(ttl),
+			}
+			result := config.DB.Clauses(clause.OnConflict{DoNothing: true}).Create(&record)
+			if result.Error != nil {
+				return echo.NewHTTPError(http.StatusInternalServerError, dto.ErrorResponse{
+					Message: "Failed to store Idempotency-Key",
+					Details: result.Error.Error(),
+				})
+			}
+
+			if result.RowsAffected == 0 {
+				var existing model.IdempotencyKey
+				if err := config.DB.Where("user_id = ? AND key = ?", userID, key).First(&existing).Error; err != nil {
+					return echo.NewHTTPError(http.StatusInternalServerError, dto.ErrorResponse{
+						Message: "Failed to check Idempotency-Key",
+						Details: err.Error(),
+					})
+				}
+
+				switch {
+				case existing.Fingerprint != fingerprint:
+					return echo.NewHTTPError(http.StatusUnprocessableEntity, dto.ErrorResponse{
+						Message: "Idempotency-Key was already used for a different request",
+					})
+				case existing.StatusCode == 0:
+					c.Response().Header().Set("Retry-After", strconv.Itoa(idempotencyRetryAfter))
+					return echo.NewHTTPError(http.StatusConflict, dto.ErrorResponse{
+						Message: "A request with this Idempotency-Key is still in progress",
+					})
+				}
+
+				c.Response().Header().Set("Idempotent-Replayed", "true")
+				return c.Blob(existing.StatusCode, existing.ContentType, existing.ResponseBody)
+			}
+
+			// Release the key if the handler panics, so the request can be retried
+			stored := false
+			defer func() {
+				if !stored {
+					config.DB.Delete(&record)
+				}
+			}()
+
+			recorder := &responseRecorder{ResponseWriter: c.Response().Writer}
+			c.Response().Writer = recorder
+
+			// Errors are rendered here rather than by Echo once the middleware returns,
+			// so error responses are recorded too
+			if err := next(c); err != nil {
+				c.Error(err)
+			}
+
+			stored = true
+			status := c.Response().Status
+			if status >= http.StatusInternalServerError {
+				err = config.DB.Delete(&record).Error
+			} else {
+				err = config.DB.Model(&record).Updates(map[string]interface{}{
+					"status_code":   status,
+					"content_type":  c.Response().Header().Get(echo.HeaderContentType),
+					"response_body": recorder.body.Bytes(),
+				}).Error
+			}
+			if err != nil {
+				MakeLogEntry(c).Error("failed to store idempotent response: " + err.Error())
+			}
+			return nil
+		}
+	}
+}
@@ -1,10 +1,13 @@
 package model
 
 type Exercise struct {
-	ID          uint   `gorm:"primaryKey"`
-	WorkoutID   uint   `gorm:"not null"`
-	Name        string `gorm:"not null"`
-	Description string `gorm:"not null"`
+	ID           uint   `gorm:"primaryKey"`
+	WorkoutID    uint   `gorm:"not null"`
+	Name         string `gorm:"not null"`
+	Description  string `gorm:"not null"`
+	DefinitionID *uint  `gorm:"index"` // FK to the ExerciseDefinition catalog
+	GroupID      *uint  `gorm:"index"` // optional FK to ExerciseGroup
+	GroupOrder   int    // position inside the group
 
 	Workout      Workout       `gorm:"foreignKey:WorkoutID"`
 	ExerciseLogs []ExerciseLog `gorm:"foreignKey:ExerciseID"`
@@ -0,0 +1,30 @@
+package model
+
+// Exercise types, each one records different fields on its logs
+const (
+	TypeStrength       = "strength"        // sets x reps @ weight
+	TypeBodyweight     = "bodyweight"      // sets x reps, optional added or assisted weight
+	TypeTimedHold      = "timed_hold"      // sets of a held duration
+	TypeDistanceCardio = "distance_cardio" // distance over a duration
+	TypeIntervals      = "intervals"       // sets of timed work intervals
+)
+
+// ExerciseTypes lists every valid exercise type
+var ExerciseTypes = []string{TypeStrength, TypeBodyweight, TypeTimedHold, TypeDistanceCardio, TypeIntervals}
+
+// ExerciseDefinition is a canonical catalog entry that workout exercises reference.
+// System-provided definitions have no owner, custom ones belong to a user.
+type ExerciseDefinition struct {
+	ID              uint   `gorm:"primaryKey" json:"id"`
+	UserID          *uint  `gorm:"index" json:"user_id"` // nil for system definitions
+	Name            string `gorm:"not null" json:"name"`
+	Description     string `gorm:"not null" json:"description"`
+	MovementPattern string `json:"movement_pattern"`
+	ExerciseType    string `gorm:"not null;default:strength" json:"exercise_type"`
+
+	User             *User         `gorm:"foreignKey:UserID" json:"-"`
+	Exercises        []Exercise    `gorm:"foreignKey:DefinitionID" json:"-"`
+	PrimaryMuscles   []MuscleGroup `gorm:"many2many:exercise_definition_primary_muscles" json:"primary_muscles"`
+	SecondaryMuscles []MuscleGroup `gorm:"many2many:exercise_definition_secondary_muscles" json:"secondary_muscles"`
+	Equipment        []Equipment   `gorm:"many2many:exercise_definition_equipment" json:"equipment"`
+}
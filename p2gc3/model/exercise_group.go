@@ -0,0 +1,21 @@
+package model
+
+// Supported exercise group types
+const (
+	GroupTypeSuperset = "superset"
+	GroupTypeCircuit  = "circuit"
+	GroupTypeGiantSet = "giant_set"
+)
+
+// ExerciseGroup bundles exercises of a workout that are performed back-to-back
+type ExerciseGroup struct {
+	ID          uint   `gorm:"primaryKey" json:"id"`
+	WorkoutID   uint   `gorm:"not null;index" json:"workout_id"`
+	Name        string `json:"name"`
+	Type        string `gorm:"not null" json:"type"`
+	Rounds      int    `gorm:"not null" json:"rounds"`
+	RestSeconds int    `gorm:"not null" json:"rest_seconds"` // rest between rounds
+
+	Workout   Workout    `gorm:"foreignKey:WorkoutID" json:"-"`
+	Exercises []Exercise `gorm:"foreignKey:GroupID" json:"-"`
+}
@@ -3,14 +3,32 @@ package model
 import "time"
 
 type ExerciseLog struct {
-	ID         uint      `gorm:"primaryKey"`
-	ExerciseID uint      `gorm:"not null"` // FK to Exercise
-	UserID     uint      `gorm:"not null"` // FK to User
-	SetCount   int       `gorm:"not null"`
-	RepCount   int       `gorm:"not null"`
-	Weight     int       `gorm:"not null"`
-	CreatedAt  time.Time `gorm:"not null" json:"created_at"`
+	ID           uint    `gorm:"primaryKey"`
+	ExerciseID   uint    `gorm:"not null"` // FK to Exercise
+	UserID       uint    `gorm:"not null"` // FK to User
+	SessionID    *uint   `gorm:"index"`    // optional FK to WorkoutSession
+	DefinitionID *uint   `gorm:"index"`    // catalog definition the exercise referenced when logged
+	SetCount     int     `gorm:"not null"`
+	RepCount     int     `gorm:"not null"`
+	Weight       float64 `gorm:"type:numeric(10,3);not null"` // kg
+
+	// Type specific fields, zero when not relevant for the exercise type
+	DurationSeconds  int     // timed hold, cardio and interval work duration
+	DistanceMeters   int     // distance cardio
+	PaceSecondsPerKm int     // distance cardio, derived from duration and distance
+	AddedWeight      float64 `gorm:"type:numeric(10,3)"` // kg, bodyweight exercises with a vest or belt
+	AssistedWeight   float64 `gorm:"type:numeric(10,3)"` // kg, bodyweight exercises with band or machine assistance
+
+	// How the exercise went, all optional
+	Notes           string   `gorm:"type:text"`
+	Tempo           string   // eccentric-pause-concentric-pause in seconds, e.g. 3-1-1-0
+	RestSeconds     int      // rest taken between sets
+	PerceivedEffort *float64 // 1-10 for the whole exercise, sets carry their own RPE
+
+	Round     int       // round number when the exercise is part of a group, 0 otherwise
+	CreatedAt time.Time `gorm:"not null" json:"created_at"`
 
 	User     User     `gorm:"foreignKey:UserID"`
 	Exercise Exercise `gorm:"foreignKey:ExerciseID"`
+	Sets     []LogSet `gorm:"foreignKey:ExerciseLogID;constraint:OnDelete:CASCADE"` // empty for aggregate-only logs
 }
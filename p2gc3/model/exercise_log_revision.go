@@ -0,0 +1,15 @@
+package model
+
+import "time"
+
+// ExerciseLogRevision stores the fields changed by one edit of an exercise log.
+// Revisions are removed together with their log by the database.
+type ExerciseLogRevision struct {
+	ID            uint      `gorm:"primaryKey" json:"id"`
+	ExerciseLogID uint      `gorm:"not null;index" json:"exercise_log_id"`
+	UserID        uint      `gorm:"not null" json:"user_id"`
+	Changes       string    `gorm:"type:jsonb;not null" json:"changes"` // {"field": {"old": x, "new": y}}
+	CreatedAt     time.Time `gorm:"not null" json:"created_at"`
+
+	ExerciseLog ExerciseLog `gorm:"foreignKey:ExerciseLogID;constraint:OnDelete:CASCADE" json:"-"`
+}
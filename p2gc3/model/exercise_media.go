@@ -0,0 +1,25 @@
+package model
+
+import "time"
+
+// Kinds of media that can be attached to an exercise
+const (
+	MediaKindImage = "image"
+	MediaKindVideo = "video"
+)
+
+// ExerciseMedia is a demo image or video attached to an exercise, stored in the blob store
+type ExerciseMedia struct {
+	ID           uint      `gorm:"primaryKey" json:"id"`
+	ExerciseID   uint      `gorm:"not null;index" json:"exercise_id"`
+	UserID       uint      `gorm:"not null" json:"user_id"` // uploader
+	Kind         string    `gorm:"not null" json:"kind"`
+	ContentType  string    `gorm:"not null" json:"content_type"`
+	SizeBytes    int64     `gorm:"not null" json:"size_bytes"`
+	OriginalName string    `json:"original_name"`
+	StorageKey   string    `gorm:"not null" json:"-"`
+	ThumbnailKey string    `json:"-"` // empty for videos
+	CreatedAt    time.Time `gorm:"not null" json:"created_at"`
+
+	Exercise Exercise `gorm:"foreignKey:ExerciseID" json:"-"`
+}
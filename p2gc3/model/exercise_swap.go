@@ -0,0 +1,16 @@
+package model
+
+import "time"
+
+// ExerciseSwap records a workout exercise being switched to another catalog definition.
+// The exercise row, and so its logs, stay the same; logs keep the definition they were performed with.
+type ExerciseSwap struct {
+	ID               uint      `gorm:"primaryKey" json:"id"`
+	ExerciseID       uint      `gorm:"not null;index" json:"exercise_id"`
+	UserID           uint      `gorm:"not null" json:"user_id"`
+	FromDefinitionID *uint     `json:"from_definition_id"`
+	ToDefinitionID   uint      `gorm:"not null" json:"to_definition_id"`
+	CreatedAt        time.Time `gorm:"not null" json:"created_at"`
+
+	Exercise Exercise `gorm:"foreignKey:ExerciseID" json:"-"`
+}
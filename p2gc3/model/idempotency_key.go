@@ -0,0 +1,17 @@
+package model
+
+import "time"
+
+// IdempotencyKey remembers a write request sent with an Idempotency-Key header and its response,
+// so a retry of the same request gets the original response instead of running twice
+type IdempotencyKey struct {
+	ID           uint      `gorm:"primaryKey"`
+	UserID       uint      `gorm:"not null;uniqueIndex:idx_idempotency_keys_user_key"`
+	Key          string    `gorm:"not null;uniqueIndex:idx_idempotency_keys_user_key"`
+	Fingerprint  string    `gorm:"not null"` // sha256 of method, path and body
+	StatusCode   int       `gorm:"not null"` // 0 while the original request is in progress
+	ContentType  string    `gorm:"not null"`
+	ResponseBody []byte    `gorm:"type:bytea"`
+	CreatedAt    time.Time `gorm:"not null"`
+	ExpiresAt    time.Time `gorm:"not null;index"`
+}
@@ -0,0 +1,24 @@
+package model
+
+// Supported set types
+const (
+	SetTypeWarmup  = "warmup"
+	SetTypeWorking = "working"
+	SetTypeDrop    = "drop"
+	SetTypeFailure = "failure"
+)
+
+// SetTypes lists every supported set type
+var SetTypes = []string{SetTypeWarmup, SetTypeWorking, SetTypeDrop, SetTypeFailure}
+
+// LogSet is one set of an exercise log, sets of a log are ordered by Position
+type LogSet struct {
+	ID            uint     `gorm:"primaryKey"`
+	ExerciseLogID uint     `gorm:"not null;index"`
+	Position      int      `gorm:"not null"` // 1-based order within the log
+	SetType       string   `gorm:"not null;default:working"`
+	RepCount      int      `gorm:"not null"`
+	Weight        float64  `gorm:"type:numeric(10,3);not null"` // kg, added weight for bodyweight exercises
+	RPE           *float64 // rate of perceived exertion, 1-10
+	RIR           *int     // reps in reserve
+}
@@ -0,0 +1,33 @@
+package model
+
+import "time"
+
+// Personal record types
+const (
+	RecordHeaviestWeight = "heaviest_weight" // heaviest set
+	RecordMostReps       = "most_reps"       // most reps in a set at one weight
+	RecordEstimated1RM   = "estimated_1rm"   // best estimated one-rep max of a set
+	RecordBestVolume     = "best_volume"     // most weight x reps over the sets of one log
+)
+
+// RecordTypes lists every personal record type
+var RecordTypes = []string{RecordHeaviestWeight, RecordMostReps, RecordEstimated1RM, RecordBestVolume}
+
+// PersonalRecord is a best performance set by a log. Records of an exercise are kept per catalog
+// entry, so they carry over between workouts; a new row is added each time a record is beaten.
+// Records are removed together with the log that set them by the database.
+type PersonalRecord struct {
+	ID            uint      `gorm:"primaryKey"`
+	UserID        uint      `gorm:"not null;index"`
+	DefinitionID  *uint     `gorm:"index"` // catalog entry, nil for exercises not linked to the catalog
+	ExerciseID    uint      `gorm:"not null;index"`
+	ExerciseLogID uint      `gorm:"not null;index"`
+	RecordType    string    `gorm:"not null"`
+	Value         float64   `gorm:"type:numeric(12,3);not null"` // kg, reps for most_reps
+	PreviousValue *float64  `gorm:"type:numeric(12,3)"`          // record that was beaten, nil for the first one
+	Weight        float64   `gorm:"type:numeric(10,3);not null"` // kg of the set, the weight most_reps is counted at
+	RepCount      int       `gorm:"not null"`                    // reps of the set, total reps for best_volume
+	AchievedAt    time.Time `gorm:"not null;index"`              // when the log was recorded
+
+	ExerciseLog ExerciseLog `gorm:"foreignKey:ExerciseLogID;constraint:OnDelete:CASCADE"`
+}
@@ -0,0 +1,47 @@
+package model
+
+import "time"
+
+// Program is a multi-week training block composed of weeks and days referencing workouts
+type Program struct {
+	ID          uint   `gorm:"primaryKey" json:"id"`
+	UserID      uint   `gorm:"not null;index" json:"user_id"` // owner of the program
+	Name        string `gorm:"not null" json:"name"`
+	Description string `gorm:"not null" json:"description"`
+
+	User  User          `gorm:"foreignKey:UserID" json:"-"`
+	Weeks []ProgramWeek `gorm:"foreignKey:ProgramID" json:"weeks"`
+}
+
+// ProgramWeek holds the periodization modifiers applied to every day of the week
+type ProgramWeek struct {
+	ID                uint    `gorm:"primaryKey" json:"id"`
+	ProgramID         uint    `gorm:"not null;index" json:"program_id"`
+	WeekNumber        int     `gorm:"not null" json:"week_number"`
+	IntensityModifier float64 `gorm:"not null;default:1" json:"intensity_modifier"` // multiplier on weight
+	VolumeModifier    float64 `gorm:"not null;default:1" json:"volume_modifier"`    // multiplier on sets
+
+	Days []ProgramDay `gorm:"foreignKey:ProgramWeekID" json:"days"`
+}
+
+// ProgramDay schedules a workout on a day (1-7) of a program week
+type ProgramDay struct {
+	ID            uint `gorm:"primaryKey" json:"id"`
+	ProgramWeekID uint `gorm:"not null;index" json:"program_week_id"`
+	DayNumber     int  `gorm:"not null" json:"day_number"`
+	WorkoutID     uint `gorm:"not null" json:"workout_id"`
+
+	Workout Workout `gorm:"foreignKey:WorkoutID" json:"-"`
+}
+
+// ProgramEnrollment tracks a user following a program from a start date
+type ProgramEnrollment struct {
+	ID        uint      `gorm:"primaryKey" json:"id"`
+	UserID    uint      `gorm:"not null;index" json:"user_id"`
+	ProgramID uint      `gorm:"not null" json:"program_id"`
+	StartDate time.Time `gorm:"not null" json:"start_date"`
+	Active    bool      `gorm:"not null;default:true" json:"active"`
+
+	User    User    `gorm:"foreignKey:UserID" json:"-"`
+	Program Program `gorm:"foreignKey:ProgramID" json:"-"`
+}
@@ -0,0 +1,22 @@
+package model
+
+import "time"
+
+// Entity types tracked for offline sync
+const (
+	SyncWorkout  = "workout"
+	SyncExercise = "exercise"
+	SyncLog      = "log"
+)
+
+// SyncEntity is the sync state of a workout, exercise or log. Database triggers keep it up to
+// date on every write: each change gives the entity a new version, deleting it leaves a tombstone.
+type SyncEntity struct {
+	EntityType string    `gorm:"primaryKey"`
+	EntityID   uint      `gorm:"primaryKey;autoIncrement:false"`
+	UserID     uint      `gorm:"not null;index:idx_sync_entities_user_version,priority:1;uniqueIndex:idx_sync_entities_user_client,priority:1"`
+	Version    int64     `gorm:"not null;index:idx_sync_entities_user_version,priority:2"` // from sync_version_seq, grows with every change
+	Deleted    bool      `gorm:"not null;default:false"`
+	ClientID   *string   `gorm:"type:uuid;uniqueIndex:idx_sync_entities_user_client,priority:2"` // set when a client created it through sync
+	ChangedAt  time.Time `gorm:"not null"`
+}
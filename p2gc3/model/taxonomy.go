@@ -0,0 +1,39 @@
+package model
+
+// Body regions a muscle group belongs to
+const (
+	RegionUpper = "upper"
+	RegionLower = "lower"
+	RegionCore  = "core"
+)
+
+// Movement patterns an exercise definition can follow
+const (
+	PatternHorizontalPush = "horizontal_push"
+	PatternVerticalPush   = "vertical_push"
+	PatternHorizontalPull = "horizontal_pull"
+	PatternVerticalPull   = "vertical_pull"
+	PatternSquat          = "squat"
+	PatternHinge          = "hinge"
+	PatternLunge          = "lunge"
+	PatternIsolation      = "isolation"
+	PatternCore           = "core"
+	PatternCardio         = "cardio"
+)
+
+// MovementPatterns lists every valid movement pattern
+var MovementPatterns = []string{
+	PatternHorizontalPush, PatternVerticalPush, PatternHorizontalPull, PatternVerticalPull,
+	PatternSquat, PatternHinge, PatternLunge, PatternIsolation, PatternCore, PatternCardio,
+}
+
+type MuscleGroup struct {
+	ID     uint   `gorm:"primaryKey" json:"id"`
+	Name   string `gorm:"uniqueIndex;not null" json:"name"`
+	Region string `gorm:"not null" json:"region"`
+}
+
+type Equipment struct {
+	ID   uint   `gorm:"primaryKey" json:"id"`
+	Name string `gorm:"uniqueIndex;not null" json:"name"`
+}
@@ -1,12 +1,15 @@
 package model
 
 type User struct {
-	ID       uint   `gorm:"primaryKey" json:"id"`
-	Email    string `gorm:"unique;not null" json:"email"`
-	FullName string `gorm:"not null" json:"full_name"`
-	Password string `gorm:"not null" json:"-"` // Hidden in JSON responses
-	Weight   int    `gorm:"not null" json:"weight"`
-	Height   int    `gorm:"not null" json:"height"`
+	ID       uint    `gorm:"primaryKey" json:"id"`
+	Email    string  `gorm:"unique;not null" json:"email"`
+	FullName string  `gorm:"not null" json:"full_name"`
+	Password string  `gorm:"not null" json:"-"`                         // Hidden in JSON responses
+	Weight   float64 `gorm:"type:numeric(10,3);not null" json:"weight"` // body weight in kg
+	Height   int     `gorm:"not null" json:"height"`
+
+	WeightUnit string `gorm:"not null;default:kg" json:"weight_unit"` // preferred unit for weights in responses, kg or lb
+	Timezone   string `gorm:"not null;default:UTC" json:"timezone"`   // IANA timezone days are counted in
 
 	Workouts     []Workout     `gorm:"foreignKey:UserID" json:"-"`
 	ExerciseLogs []ExerciseLog `gorm:"foreignKey:UserID" json:"-"`
@@ -0,0 +1,16 @@
+package model
+
+import "time"
+
+// WorkoutSession groups the exercise logs recorded during one training event
+type WorkoutSession struct {
+	ID        uint       `gorm:"primaryKey" json:"id"`
+	UserID    uint       `gorm:"not null;index" json:"user_id"`
+	WorkoutID uint       `gorm:"not null" json:"workout_id"` // planned workout
+	StartedAt time.Time  `gorm:"not null" json:"started_at"`
+	EndedAt   *time.Time `json:"ended_at"` // nil while the session is in progress
+
+	User         User          `gorm:"foreignKey:UserID" json:"-"`
+	Workout      Workout       `gorm:"foreignKey:WorkoutID" json:"-"`
+	ExerciseLogs []ExerciseLog `gorm:"foreignKey:SessionID" json:"-"`
+}
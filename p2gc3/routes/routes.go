@@ -4,37 +4,119 @@ import (
 	"os"
 	"p2gc3/handler"
 	"p2gc3/middleware"
+	"time"
 
 	_ "p2gc3/docs"
 
 	"github.com/labstack/echo/v4"
+	echoMiddleware "github.com/labstack/echo/v4/middleware"
 	echoSwagger "github.com/swaggo/echo-swagger"
 )
 
+// idempotencyTTL is how long responses of requests with an Idempotency-Key are replayed
+const idempotencyTTL = 24 * time.Hour
+
+// mediaBodyLimit caps media upload requests before they are read, the largest video plus multipart overhead
+const mediaBodyLimit = "55M"
+
+// importBodyLimit caps import requests before they are read, the largest CSV plus multipart overhead
+const importBodyLimit = "12M"
+
 func AllRoutes(e *echo.Echo) {
 	e.GET("/swagger/*", echoSwagger.WrapHandler)
 
+	// Signed media downloads, authorized by the URL signature instead of a token
+	mediaGroup := e.Group("/media")
+	mediaGroup.GET("/:id", handler.DownloadMedia)
+	mediaGroup.GET("/:id/thumbnail", handler.DownloadMedia)
+
 	userGroup := e.Group("/users")
 	userGroup.POST("/register", handler.Register)
 	userGroup.POST("/login", handler.Login)
 	userGroup.GET("", handler.UserInfo, middleware.JWTMiddleware(os.Getenv("JWT_SECRET")))
+	userGroup.PATCH("/preferences", handler.UpdateUserPreferences, middleware.JWTMiddleware(os.Getenv("JWT_SECRET")))
 
 	apiGroup := e.Group("/api", middleware.JWTMiddleware(os.Getenv("JWT_SECRET")))
 
+	// Retried creates with the same Idempotency-Key get the original response
+	idempotent := middleware.Idempotency(idempotencyTTL)
+
 	// Group for /api/workouts
 	workoutGroup := apiGroup.Group("/workouts")
-	workoutGroup.POST("", handler.CreateWorkout)
+	workoutGroup.POST("", handler.CreateWorkout, idempotent)
 	workoutGroup.GET("", handler.GetWorkout)
 	workoutGroup.GET("/:id", handler.GetWorkoutByID)
 	workoutGroup.PUT("/:id", handler.UpdateWorkout)
 	workoutGroup.DELETE("/:id", handler.DeleteWorkout)
+	workoutGroup.POST("/:id/groups", handler.CreateExerciseGroup)
+	workoutGroup.DELETE("/:id/groups/:groupId", handler.DeleteExerciseGroup)
+
+	workoutGroup.GET("/:id/exercises", handler.GetWorkoutExercises)
 
-	exerciseGroup := apiGroup.Group("/exercise", middleware.JWTMiddleware(os.Getenv("JWT_SECRET")))
+	exerciseGroup := apiGroup.Group("/exercises")
 	exerciseGroup.POST("", handler.CreateExercise)
+	exerciseGroup.GET("/:id", handler.GetExerciseByID)
+	exerciseGroup.PUT("/:id", handler.UpdateExercise)
+	exerciseGroup.PATCH("/:id", handler.PatchExercise)
 	exerciseGroup.DELETE("/:id", handler.DeleteExercise)
+	exerciseGroup.GET("/:id/next-target", handler.GetNextTarget)
+	exerciseGroup.GET("/:id/alternatives", handler.GetExerciseAlternatives)
+	exerciseGroup.POST("/:id/swap", handler.SwapExercise)
+	exerciseGroup.POST("/:id/media", handler.UploadExerciseMedia, echoMiddleware.BodyLimit(mediaBodyLimit))
+	exerciseGroup.GET("/:id/media", handler.GetExerciseMedia)
+	exerciseGroup.DELETE("/:id/media/:mediaId", handler.DeleteExerciseMedia)
+
+	catalogGroup := apiGroup.Group("/catalog")
+	catalogGroup.GET("", handler.GetCatalog)
+	catalogGroup.POST("", handler.CreateCatalogEntry)
+	catalogGroup.GET("/:id", handler.GetCatalogEntry)
+	catalogGroup.PUT("/:id/taxonomy", handler.UpdateCatalogTaxonomy)
+	apiGroup.GET("/taxonomy", handler.GetTaxonomy)
+
+	apiGroup.GET("/search", handler.Search)
+	apiGroup.GET("/export", handler.ExportLogs)
+	apiGroup.GET("/records", handler.GetPersonalRecords)
+
+	// Group for /api/analytics
+	analyticsGroup := apiGroup.Group("/analytics")
+	analyticsGroup.GET("/volume", handler.GetVolumeAnalytics)
+	analyticsGroup.GET("/adherence", handler.GetAdherenceAnalytics)
+
+	// Deprecated: singular alias kept for older clients, use /api/exercises
+	legacyExerciseGroup := apiGroup.Group("/exercise", middleware.Deprecated("/api/exercises"))
+	legacyExerciseGroup.POST("", handler.CreateExercise)
+	legacyExerciseGroup.DELETE("/:id", handler.DeleteExercise)
 
 	logGroup := apiGroup.Group("/logs")
-	logGroup.POST("", handler.CreateExerciseLog)
+	logGroup.POST("", handler.CreateExerciseLog, idempotent)
+	logGroup.GET("", handler.GetExerciseLogs)
+	logGroup.GET("/:id", handler.GetExerciseLogByID)
+	logGroup.PUT("/:id", handler.UpdateExerciseLog)
+	logGroup.PATCH("/:id", handler.PatchExerciseLog)
+	logGroup.GET("/:id/history", handler.GetExerciseLogHistory)
 	logGroup.DELETE("/:id", handler.DeleteExerciseLog)
 
+	// Group for /api/sync, offline-first clients pull changes and push what they did offline
+	syncGroup := apiGroup.Group("/sync")
+	syncGroup.GET("", handler.PullSyncChanges)
+	syncGroup.POST("", handler.PushSyncChanges)
+
+	// Group for /api/import
+	importGroup := apiGroup.Group("/import", echoMiddleware.BodyLimit(importBodyLimit))
+	importGroup.POST("/logs", handler.ImportLogs)
+	importGroup.POST("/strong", handler.ImportStrong)
+	importGroup.POST("/hevy", handler.ImportHevy)
+
+	sessionGroup := apiGroup.Group("/sessions")
+	sessionGroup.POST("", handler.StartWorkoutSession)
+	sessionGroup.GET("/:id", handler.GetWorkoutSession)
+	sessionGroup.POST("/:id/finish", handler.FinishWorkoutSession)
+
+	programGroup := apiGroup.Group("/programs")
+	programGroup.POST("", handler.CreateProgram)
+	programGroup.GET("", handler.GetPrograms)
+	programGroup.GET("/today", handler.GetTodayWorkout)
+	programGroup.GET("/:id", handler.GetProgramByID)
+	programGroup.POST("/:id/enroll", handler.EnrollProgram)
+
 }